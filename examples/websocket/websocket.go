@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/AndreasChristianson/gopher-pipes/reactive"
+	"github.com/AndreasChristianson/gopher-pipes/wsbridge"
+	"github.com/redis/go-redis/v9"
+)
+
+func main() {
+	ctx := context.Background()
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	lastID := "$"
+	pipe := reactive.FromGeneratorWithDefaultBackoff(func() (*string, error) {
+		res, err := client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{"events", lastID},
+			Count:   1,
+			Block:   time.Second,
+		}).Result()
+		if err == redis.Nil {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		msg := res[0].Messages[0]
+		lastID = msg.ID
+		val := fmt.Sprint(msg.Values["data"])
+		return &val, nil
+	})
+	broadcaster := wsbridge.NewBroadcaster(pipe, func(item string) ([]byte, error) {
+		return []byte(item), nil
+	}, wsbridge.Options{Policy: wsbridge.DropOldest})
+	pipe.Start()
+	http.Handle("/events", broadcaster)
+	fmt.Println(http.ListenAndServe(":8080", nil))
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/AndreasChristianson/gopher-pipes/reactive"
+	"github.com/AndreasChristianson/gopher-pipes/redisx"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -203,3 +204,44 @@ func TestMapPipelineWithPubSub(t *testing.T) {
 	defer mu.Unlock()
 	assert.Equal(t, []int{2, 4, 6}, results)
 }
+
+// TestPubSubViaFromPubSub verifies that Redis pub/sub messages flow through a redisx.FromPubSub source.
+func TestPubSubViaFromPubSub(t *testing.T) {
+	ctx := context.Background()
+	client := setupRedis(t)
+
+	t.Log("subscribing to test-channel")
+	source := redisx.FromPubSub(client, redisx.Payload, redisx.Channel("test-channel"))
+	var mu sync.Mutex
+	results := make([]string, 0)
+	source.Observe(func(s string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		t.Logf("pipe observed: %s", s)
+		results = append(results, s)
+		return nil
+	})
+	source.Start()
+
+	t.Log("waiting for subscription")
+	assert.Eventually(t, func() bool {
+		return client.PubSubNumSub(ctx, "test-channel").Val()["test-channel"] == 1
+	}, 3*time.Second, 50*time.Millisecond)
+
+	t.Log("publishing messages")
+	require.NoError(t, client.Publish(ctx, "test-channel", "hello").Err())
+	require.NoError(t, client.Publish(ctx, "test-channel", "world").Err())
+
+	t.Log("waiting for results")
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(results) == 2
+	}, 3*time.Second, 50*time.Millisecond)
+	require.NoError(t, source.Cancel())
+	source.AwaitCompletion()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"hello", "world"}, results)
+}
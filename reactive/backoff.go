@@ -0,0 +1,125 @@
+package reactive
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Backoff decides how long to wait after consecutive failures.
+type Backoff interface {
+	// Next returns the duration to wait after the provided number of consecutive failures, starting at one.
+	Next(attempt int) time.Duration
+	// Reset clears any state kept between calls to Next. It is called once a failure streak ends.
+	Reset()
+}
+
+type constantBackoff struct {
+	delay time.Duration
+}
+
+// ConstantBackoff returns a [Backoff] that always waits the provided delay.
+func ConstantBackoff(delay time.Duration) Backoff {
+	return &constantBackoff{delay: delay}
+}
+
+func (c *constantBackoff) Next(int) time.Duration {
+	return c.delay
+}
+
+func (c *constantBackoff) Reset() {}
+
+type linearBackoff struct {
+	initial   time.Duration
+	increment time.Duration
+	maxDelay  time.Duration
+}
+
+// LinearBackoff returns a [Backoff] that waits initial after the first failure and increment longer after each
+// subsequent failure, capped at maxDelay. A maxDelay of zero means no limit.
+func LinearBackoff(initial time.Duration, increment time.Duration, maxDelay time.Duration) Backoff {
+	return &linearBackoff{initial: initial, increment: increment, maxDelay: maxDelay}
+}
+
+func (l *linearBackoff) Next(attempt int) time.Duration {
+	delay := l.initial + time.Duration(attempt-1)*l.increment
+	if l.maxDelay > 0 {
+		return min(delay, l.maxDelay)
+	}
+	return delay
+}
+
+func (l *linearBackoff) Reset() {}
+
+type exponentialBackoff struct {
+	multiplier time.Duration
+	maxDelay   time.Duration
+}
+
+// ExponentialBackoff returns a [Backoff] that waits m*2^e, where m is the multiplier and e is the attempt, capped at
+// maxDelay. A maxDelay of zero means no limit.
+func ExponentialBackoff(multiplier time.Duration, maxDelay time.Duration) Backoff {
+	return &exponentialBackoff{multiplier: multiplier, maxDelay: maxDelay}
+}
+
+func (e *exponentialBackoff) Next(attempt int) time.Duration {
+	return exponentialDelay(e.multiplier, e.maxDelay, attempt)
+}
+
+func (e *exponentialBackoff) Reset() {}
+
+type fullJitterBackoff struct {
+	exponentialBackoff
+}
+
+// FullJitterBackoff returns a [Backoff] that waits a random duration between zero and the delay [ExponentialBackoff]
+// would wait. Spreading retries this way prevents many clients from retrying in lockstep.
+func FullJitterBackoff(multiplier time.Duration, maxDelay time.Duration) Backoff {
+	return &fullJitterBackoff{exponentialBackoff{multiplier: multiplier, maxDelay: maxDelay}}
+}
+
+func (f *fullJitterBackoff) Next(attempt int) time.Duration {
+	delay := f.exponentialBackoff.Next(attempt)
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay)
+}
+
+type decorrelatedJitterBackoff struct {
+	base     time.Duration
+	maxDelay time.Duration
+	lock     sync.Mutex
+	previous time.Duration
+}
+
+// DecorrelatedJitterBackoff returns a [Backoff] that waits a random duration between base and three times the
+// previous wait, capped at maxDelay. A maxDelay of zero means no limit.
+func DecorrelatedJitterBackoff(base time.Duration, maxDelay time.Duration) Backoff {
+	return &decorrelatedJitterBackoff{base: base, maxDelay: maxDelay, previous: base}
+}
+
+func (d *decorrelatedJitterBackoff) Next(int) time.Duration {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delay := d.base
+	upper := time.Duration(math.MaxInt64) - d.base
+	if d.previous < math.MaxInt64/3 {
+		upper = 3*d.previous - d.base
+	}
+	if upper > 0 {
+		delay += rand.N(upper)
+	}
+	if d.maxDelay > 0 {
+		delay = min(delay, d.maxDelay)
+	}
+	d.previous = delay
+	return delay
+}
+
+func (d *decorrelatedJitterBackoff) Reset() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.previous = d.base
+}
@@ -0,0 +1,88 @@
+package reactive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestConstantBackoff(t *testing.T) {
+	underTest := ConstantBackoff(time.Second)
+	assert.Equal(t, time.Second, underTest.Next(1))
+	assert.Equal(t, time.Second, underTest.Next(10))
+	underTest.Reset()
+}
+
+func TestLinearBackoff(t *testing.T) {
+	underTest := LinearBackoff(time.Second, 2*time.Second, 6*time.Second)
+	assert.Equal(t, time.Second, underTest.Next(1))
+	assert.Equal(t, 3*time.Second, underTest.Next(2))
+	assert.Equal(t, 6*time.Second, underTest.Next(10))
+	assert.Equal(t, 19*time.Second, LinearBackoff(time.Second, 2*time.Second, 0).Next(10))
+	underTest.Reset()
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	underTest := ExponentialBackoff(125*time.Millisecond, 10*time.Second)
+	assert.Equal(t, 250*time.Millisecond, underTest.Next(1))
+	assert.Equal(t, time.Second, underTest.Next(3))
+	assert.Equal(t, 10*time.Second, underTest.Next(10))
+	underTest.Reset()
+}
+
+func TestExponentialBackoff_UnlimitedDoesNotOverflow(t *testing.T) {
+	underTest := ExponentialBackoff(125*time.Millisecond, 0)
+	previous := time.Duration(0)
+	for _, attempt := range []int{10, 36, 37, 64, 1000} {
+		delay := underTest.Next(attempt)
+		assert.GreaterOrEqual(t, delay, previous, "attempt %d", attempt)
+		previous = delay
+	}
+	assert.Equal(t, time.Duration(math.MaxInt64), underTest.Next(1000))
+	assert.Positive(t, FullJitterBackoff(125*time.Millisecond, 0).Next(1000))
+	assert.Equal(t, time.Duration(math.MaxInt64), RetryPolicy{BackoffMultiplier: time.Second}.backoff(100))
+}
+
+func TestDecorrelatedJitterBackoff_UnlimitedDoesNotOverflow(t *testing.T) {
+	underTest := DecorrelatedJitterBackoff(time.Second, 0)
+	for attempt := 1; attempt < 200; attempt++ {
+		assert.GreaterOrEqual(t, underTest.Next(attempt), time.Second)
+	}
+}
+
+func TestFullJitterBackoff(t *testing.T) {
+	underTest := FullJitterBackoff(125*time.Millisecond, 10*time.Second)
+	for attempt := 1; attempt < 10; attempt++ {
+		assert.Less(t, underTest.Next(attempt), ExponentialBackoff(125*time.Millisecond, 10*time.Second).Next(attempt))
+	}
+	assert.Zero(t, FullJitterBackoff(0, 0).Next(1))
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	underTest := DecorrelatedJitterBackoff(10*time.Millisecond, time.Second)
+	previous := 10 * time.Millisecond
+	for attempt := 1; attempt < 20; attempt++ {
+		delay := underTest.Next(attempt)
+		assert.GreaterOrEqual(t, delay, 10*time.Millisecond)
+		assert.LessOrEqual(t, delay, min(3*previous, time.Second))
+		previous = delay
+	}
+	underTest.Reset()
+	assert.Less(t, underTest.Next(1), 30*time.Millisecond)
+}
+
+func TestFromGeneratorWithBackoff_Constant(t *testing.T) {
+	callCount := 0
+	underTest := FromGeneratorWithBackoff(func() (*string, error) {
+		callCount++
+		if callCount == 4 {
+			return nil, &GeneratorFinished{}
+		}
+		return nil, assert.AnError
+	}, ConstantBackoff(10*time.Millisecond))
+	start := time.Now()
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
@@ -3,22 +3,34 @@ package reactive
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 )
 
 type baseSource[T any] struct {
 	sinks          []func(T) error
 	uponClose      []func()
 	startFunc      func()
+	startOnce      sync.Once
 	lock           sync.Mutex
-	closing        bool
+	closing        atomic.Bool
+	hooksRun       bool
 	completionLock sync.Mutex
+	deadLetters    []func(Failed[T]) error
 }
 
 func (b *baseSource[T]) UponClose(hook func()) {
 	hookOnce := sync.OnceFunc(hook)
 	b.lock.Lock()
-	b.log(Debug, "Registering shutdown hook (%p) as %d", hook, len(b.uponClose))
 	defer b.lock.Unlock()
+	if b.hooksRun {
+		b.log(Debug, "Source already closed. Running shutdown hook (%p) now.", hook)
+		go func() {
+			defer b.logPanic(hookOnce)
+			hookOnce()
+		}()
+		return
+	}
+	b.log(Debug, "Registering shutdown hook (%p) as %d", hook, len(b.uponClose))
 	b.uponClose = append(b.uponClose, hookOnce)
 }
 func (b *baseSource[T]) setStart(start func()) {
@@ -30,13 +42,15 @@ func (b *baseSource[T]) log(level Level, formatString string, args ...interface{
 }
 
 func (b *baseSource[T]) Start() {
-	b.log(Info, "Starting source.")
-	go func() {
-		defer b.complete()
-		b.startFunc()
-	}()
-	b.log(Verbose, "Blocking AwaitCompletion callers.")
-	b.completionLock.Lock()
+	b.startOnce.Do(func() {
+		b.log(Info, "Starting source.")
+		go func() {
+			defer b.complete()
+			b.startFunc()
+		}()
+		b.log(Verbose, "Blocking AwaitCompletion callers.")
+		b.completionLock.Lock()
+	})
 }
 
 func (b *baseSource[T]) Observe(sink func(T) error) {
@@ -48,10 +62,14 @@ func (b *baseSource[T]) Observe(sink func(T) error) {
 
 func (b *baseSource[T]) complete() {
 	b.log(Verbose, "Marking Source as closed.")
-	b.closing = true
-	b.log(Verbose, "Running %d UponClose hooks..", len(b.uponClose))
+	b.closing.Store(true)
+	b.lock.Lock()
+	b.hooksRun = true
+	hooks := b.uponClose
+	b.lock.Unlock()
+	b.log(Verbose, "Running %d UponClose hooks..", len(hooks))
 	wg := sync.WaitGroup{}
-	for index, hook := range b.uponClose {
+	for index, hook := range hooks {
 		wg.Add(1)
 		go func(hookToRun func(), indexToRun int) {
 			defer wg.Done()
@@ -74,22 +92,27 @@ func (b *baseSource[T]) String() string {
 	return fmt.Sprintf("%p", b)
 }
 
-func (b *baseSource[T]) pump(item T) {
-	if b.closing {
+// pump sends the item to every sink and waits for them. It returns true if every sink handled the item without error.
+func (b *baseSource[T]) pump(item T) bool {
+	if b.closing.Load() {
 		b.log(Warning, "Ignoring item (%.10s). This source is closing.", item)
-		return
+		return false
 	}
 	wg := sync.WaitGroup{}
+	failed := atomic.Bool{}
 	b.log(Verbose, "Beginning to send item (%.10s)", item)
 	for _, sink := range b.sinks {
 		wg.Add(1)
 		go func(sinkToSendTo func(T) error) {
 			defer wg.Done()
-			b.sendItem(item, sinkToSendTo)
+			if !b.sendItem(item, sinkToSendTo) {
+				failed.Store(true)
+			}
 		}(sink)
 	}
 	wg.Wait()
 	b.log(Verbose, "Finished sending item (%.10s)", item)
+	return !failed.Load()
 }
 
 func (b *baseSource[T]) logPanic(risk interface{}) {
@@ -100,11 +123,20 @@ func (b *baseSource[T]) logPanic(risk interface{}) {
 
 }
 
-func (b *baseSource[T]) sendItem(item T, sink func(T) error) {
+func (b *baseSource[T]) sendItem(item T, sink func(T) error) (sent bool) {
 	b.log(Verbose, "Sending item (%.10s) to sink (%p)", item, sink)
-	defer b.logPanic(sink)
+	defer func() {
+		if err := recover(); err != nil {
+			b.log(Error, "Panic from (%p)! [%v]", sink, err)
+			b.deadLetter(item, sink, fmt.Errorf("panic: %v", err))
+			sent = false
+		}
+	}()
 	err := sink(item)
 	if err != nil {
 		b.log(Warning, "Failed to write item (%.10s) to sink (%p): [%s]", item, sink, err)
+		b.deadLetter(item, sink, err)
+		return false
 	}
+	return true
 }
@@ -2,7 +2,6 @@ package reactive
 
 import (
 	"errors"
-	"fmt"
 	"github.com/stretchr/testify/assert"
 	"strings"
 	"sync"
@@ -51,21 +50,24 @@ func TestBaseSource_HandlesHookPanic(t *testing.T) {
 }
 
 func TestBaseSource_HandlesSinkError(t *testing.T) {
-	var messages []string
-	SetLogger(func(level Level, source interface{}, messageFormat string, args ...interface{}) {
-		message := fmt.Sprintf(messageFormat, args...)
-		messages = append(messages, fmt.Sprintf("%s [%s]: %s", level, source, message))
-	})
+	messages := captureLogs(t)
 	underTest := FromSlice([]string{"test"})
 	underTest.Observe(func(string) error {
 		return errors.New("test error")
 	})
 	underTest.Start()
 	underTest.AwaitCompletion()
-	for _, message := range messages {
+	for _, message := range messages() {
 		if strings.Contains(message, "[test error]") && strings.Contains(message, "Warning") {
 			return
 		}
 	}
 	assert.Fail(t, "expected log not found")
 }
+
+func TestBaseSource_StartTwice(t *testing.T) {
+	source := Just(1)
+	source.Start()
+	source.Start()
+	source.AwaitCompletion()
+}
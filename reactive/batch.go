@@ -0,0 +1,121 @@
+package reactive
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Batch observes one [Source] and returns a [Source] of slices of the observed items. A batch is emitted when it
+// reaches maxSize items or when maxWait has elapsed since the first item of the batch was observed, whichever happens
+// first. When the observed Source closes any partial batch is emitted before the returned Source closes.
+//
+// A maxWait of zero disables the time based flush. Batch panics if maxSize is not positive.
+//
+// The returned Source is already started.
+func Batch[T any](source Source[T], maxSize int, maxWait time.Duration) Source[[]T] {
+	if maxSize < 1 {
+		panic(fmt.Sprintf("reactive: Batch maxSize must be positive, found %d", maxSize))
+	}
+	c := make(chan []T)
+	ret := fromChan(c)
+	lock := sync.Mutex{}
+	var batch []T
+	var timer Stopper
+	generation := 0
+	closed := false
+	flush := func() {
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+		generation++
+		if len(batch) == 0 {
+			return
+		}
+		ret.log(Verbose, "Flushing batch of %d items.", len(batch))
+		c <- batch
+		batch = nil
+	}
+	source.UponClose(func() {
+		lock.Lock()
+		flush()
+		closed = true
+		ret.log(Debug, "Closing batching chan (%p).", c)
+		close(c)
+		lock.Unlock()
+		ret.AwaitCompletion()
+	})
+	source.Observe(func(item T) error {
+		lock.Lock()
+		defer lock.Unlock()
+		batch = append(batch, item)
+		if len(batch) >= maxSize {
+			flush()
+			return nil
+		}
+		if timer == nil && maxWait > 0 {
+			expected := generation
+			timer = clock.AfterFunc(maxWait, func() {
+				lock.Lock()
+				defer lock.Unlock()
+				if closed || generation != expected {
+					return
+				}
+				ret.log(Debug, "Batch max wait (%s) elapsed.", maxWait)
+				flush()
+			})
+		}
+		return nil
+	})
+	ret.log(Debug, "Created batching source. maxSize (%d), maxWait (%s).", maxSize, maxWait)
+	ret.Start()
+	return ret
+}
+
+// WindowCount observes one [Source] and returns a [Source] of overlapping slices of the observed items. A new window
+// is opened every step items, and each window is emitted once it holds size items. When the observed Source closes
+// the remaining partial windows are emitted in the order they were opened.
+//
+// For example, a size of 3 and step of 1 over the items 1 through 5 emits [1 2 3], [2 3 4], [3 4 5], [4 5] and [5].
+// WindowCount panics if size or step is not positive.
+//
+// The returned Source is already started.
+func WindowCount[T any](source Source[T], size int, step int) Source[[]T] {
+	if size < 1 || step < 1 {
+		panic(fmt.Sprintf("reactive: WindowCount size and step must be positive, found %d and %d", size, step))
+	}
+	c := make(chan []T)
+	ret := fromChan(c)
+	var windows [][]T
+	count := 0
+	source.UponClose(func() {
+		for _, window := range windows {
+			if len(window) > 0 {
+				c <- window
+			}
+		}
+		windows = nil
+		ret.log(Debug, "Closing windowing chan (%p).", c)
+		close(c)
+		ret.AwaitCompletion()
+	})
+	source.Observe(func(item T) error {
+		if count%step == 0 {
+			windows = append(windows, make([]T, 0, size))
+		}
+		count++
+		for index := range windows {
+			windows[index] = append(windows[index], item)
+		}
+		for len(windows) > 0 && len(windows[0]) >= size {
+			ret.log(Verbose, "Emitting window of %d items.", size)
+			c <- windows[0]
+			windows = windows[1:]
+		}
+		return nil
+	})
+	ret.log(Debug, "Created count windowing source. size (%d), step (%d).", size, step)
+	ret.Start()
+	return ret
+}
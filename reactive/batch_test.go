@@ -0,0 +1,108 @@
+package reactive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBatch_FlushesOnSize(t *testing.T) {
+	source := Just(1, 2, 3, 4, 5)
+	batched := Batch(source, 2, time.Hour)
+	var results [][]int
+	batched.Observe(func(batch []int) error {
+		results = append(results, batch)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, results)
+}
+
+// pausingSource returns a Source of the first items, then, once resume is called, the rest. paused is closed once
+// every first item has been pumped.
+func pausingSource(first []int, rest ...int) (source Source[int], paused <-chan struct{}, resume func()) {
+	pausedChan := make(chan struct{})
+	resumeChan := make(chan struct{})
+	polls := 0
+	source = FromBatchGenerator(func() ([]int, error) {
+		polls++
+		if polls == 1 {
+			return first, nil
+		}
+		close(pausedChan)
+		<-resumeChan
+		return rest, &GeneratorFinished{}
+	})
+	return source, pausedChan, func() {
+		close(resumeChan)
+	}
+}
+
+func TestBatch_FlushesOnTime(t *testing.T) {
+	fake := useFakeClock(t)
+	source, paused, resume := pausingSource([]int{1, 2}, 3)
+	batched := Batch(source, 100, 10*time.Millisecond)
+	results := make(chan []int, 10)
+	batched.Observe(func(batch []int) error {
+		results <- batch
+		return nil
+	})
+	source.Start()
+	<-paused
+	fake.Advance(9 * time.Millisecond)
+	assert.Empty(t, results)
+	fake.Advance(time.Millisecond)
+	assert.Equal(t, []int{1, 2}, <-results)
+	resume()
+	source.AwaitCompletion()
+	assert.Equal(t, []int{3}, <-results)
+}
+
+func TestBatch_CallsUponClose(t *testing.T) {
+	source := FromSlice([]string{})
+	batched := Batch(source, 10, 0)
+	called := false
+	batched.Observe(func([]string) error {
+		t.Fail()
+		return nil
+	})
+	batched.UponClose(func() {
+		called = true
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.True(t, called)
+}
+
+func TestWindowCount_Sliding(t *testing.T) {
+	source := Just(1, 2, 3, 4, 5)
+	windowed := WindowCount(source, 3, 1)
+	var results [][]int
+	windowed.Observe(func(window []int) error {
+		results = append(results, window)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5}, {5}}, results)
+}
+
+func TestWindowCount_Skipping(t *testing.T) {
+	source := Just(1, 2, 3, 4, 5, 6)
+	windowed := WindowCount(source, 2, 3)
+	var results [][]int
+	windowed.Observe(func(window []int) error {
+		results = append(results, window)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, [][]int{{1, 2}, {4, 5}}, results)
+}
+
+func TestBatch_RejectsInvalidArguments(t *testing.T) {
+	assert.Panics(t, func() { Batch(Just(1), 0, time.Second) })
+	assert.Panics(t, func() { WindowCount(Just(1), 0, 1) })
+	assert.Panics(t, func() { WindowCount(Just(1), 2, 0) })
+}
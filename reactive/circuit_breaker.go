@@ -0,0 +1,295 @@
+package reactive
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a [CircuitBreaker].
+//   - CircuitClosed calls pass through and their outcomes are recorded.
+//   - CircuitOpen calls are rejected with [CircuitOpenError] until the cool down elapses.
+//   - CircuitHalfOpen a limited number of probe calls pass through to decide whether to close or reopen.
+type CircuitState int
+
+const (
+	// CircuitClosed lets calls through and records their outcomes.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects calls until the cool down elapses.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe calls through.
+	CircuitHalfOpen
+)
+
+// String returns the name of the CircuitState.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "Closed"
+	case CircuitOpen:
+		return "Open"
+	case CircuitHalfOpen:
+		return "HalfOpen"
+	}
+	return strconv.Itoa(int(s))
+}
+
+// CircuitOpenError is returned by calls rejected by an open [CircuitBreaker].
+type CircuitOpenError struct{}
+
+// Error implements the error interface
+func (c *CircuitOpenError) Error() string {
+	return "Circuit breaker is open"
+}
+
+// CircuitBreakerOptions configures a [CircuitBreaker]. Zero values are replaced with the documented defaults.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the fraction of failed calls, between 0 and 1, that opens the breaker. Defaults to 0.5.
+	FailureThreshold float64
+	// WindowSize is the number of most recent calls the failure rate is calculated over. Defaults to 20.
+	WindowSize int
+	// MinimumCalls is the number of calls that must be recorded before the breaker can open. Defaults to WindowSize.
+	MinimumCalls int
+	// CoolDown is how long the breaker stays open before letting probe calls through. Defaults to 30s.
+	CoolDown time.Duration
+	// HalfOpenCalls is the number of successful probe calls required to close the breaker. Defaults to 1.
+	HalfOpenCalls int
+}
+
+// CircuitBreaker stops calling a failing function for a while, giving it time to recover. Wrap sinks with
+// [BreakSink] and generators with [BreakGenerator]; several functions may share one breaker.
+type CircuitBreaker struct {
+	options        CircuitBreakerOptions
+	lock           sync.Mutex
+	state          CircuitState
+	outcomes       []bool
+	next           int
+	openedAt       time.Time
+	probesInFlight int
+	probeSuccesses int
+	// generation counts transitions, so outcomes of calls admitted in an earlier state are ignored.
+	generation uint64
+	states     *breakerStates
+	// pending holds the transitions not yet delivered to states, which are only queued once states is started.
+	pending []CircuitState
+	queued  chan struct{}
+}
+
+// breakerStates is the Source returned by [CircuitBreaker.States]. Starting it begins queueing transitions.
+type breakerStates struct {
+	*subjectSource[CircuitState]
+	breaker *CircuitBreaker
+	started bool
+}
+
+func (s *breakerStates) Start() {
+	s.breaker.lock.Lock()
+	s.started = true
+	s.breaker.lock.Unlock()
+	s.subjectSource.Start()
+}
+
+// NewCircuitBreaker returns a closed [CircuitBreaker] configured by the provided options.
+func NewCircuitBreaker(options CircuitBreakerOptions) *CircuitBreaker {
+	if options.FailureThreshold <= 0 {
+		options.FailureThreshold = 0.5
+	}
+	if options.WindowSize <= 0 {
+		options.WindowSize = 20
+	}
+	if options.MinimumCalls <= 0 || options.MinimumCalls > options.WindowSize {
+		options.MinimumCalls = options.WindowSize
+	}
+	if options.CoolDown <= 0 {
+		options.CoolDown = 30 * time.Second
+	}
+	if options.HalfOpenCalls <= 0 {
+		options.HalfOpenCalls = 1
+	}
+	ret := &CircuitBreaker{
+		options: options,
+		queued:  make(chan struct{}, 1),
+	}
+	ret.states = &breakerStates{subjectSource: newSubjectSource[CircuitState](), breaker: ret}
+	ret.states.setStart(ret.deliverStates)
+	logger(Debug, ret, "Created circuit breaker: %+v", options)
+	return ret
+}
+
+func (c *CircuitBreaker) String() string {
+	return fmt.Sprintf("breaker(%p)", c)
+}
+
+// State returns the current state of the breaker.
+func (c *CircuitBreaker) State() CircuitState {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.state
+}
+
+// States returns a [CancellableSource] of the breaker's state transitions, delivered in order on the Source's own go
+// routine. Only transitions made after the Source is started are delivered; they are queued, rather than slowing the
+// breaker, while its sinks are busy. The returned Source is shared by every caller; it completes once cancelled.
+func (c *CircuitBreaker) States() CancellableSource[CircuitState] {
+	return c.states
+}
+
+// deliverStates pumps queued transitions until the states source is cancelled, then pumps those still queued.
+func (c *CircuitBreaker) deliverStates() {
+	for {
+		select {
+		case <-c.queued:
+			c.pumpPending()
+		case <-c.states.done:
+			c.pumpPending()
+			return
+		}
+	}
+}
+
+func (c *CircuitBreaker) pumpPending() {
+	c.lock.Lock()
+	pending := c.pending
+	c.pending = nil
+	c.lock.Unlock()
+	for _, state := range pending {
+		c.states.pump(state)
+	}
+}
+
+// Execute calls the operation if the breaker allows it and records the outcome. A [CircuitOpenError] is returned
+// without calling the operation when the breaker is open.
+func (c *CircuitBreaker) Execute(operation func() error) error {
+	generation, err := c.acquire()
+	if err != nil {
+		return err
+	}
+	success := false
+	defer func() {
+		c.record(generation, success)
+	}()
+	err = operation()
+	success = err == nil
+	return err
+}
+
+// acquire admits a call, returning the generation it was admitted in, which must be passed to record.
+func (c *CircuitBreaker) acquire() (uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.state == CircuitOpen && clock.Now().Sub(c.openedAt) >= c.options.CoolDown {
+		c.transition(CircuitHalfOpen)
+	}
+	switch c.state {
+	case CircuitOpen:
+		return 0, &CircuitOpenError{}
+	case CircuitHalfOpen:
+		if c.probesInFlight+c.probeSuccesses >= c.options.HalfOpenCalls {
+			return 0, &CircuitOpenError{}
+		}
+		c.probesInFlight++
+	}
+	return c.generation, nil
+}
+
+// record counts the outcome of a call, unless the breaker has changed state since the call was admitted.
+func (c *CircuitBreaker) record(generation uint64, success bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if generation != c.generation {
+		logger(Debug, c, "Ignoring outcome of a call admitted before the last state change.")
+		return
+	}
+	switch c.state {
+	case CircuitClosed:
+		c.recordOutcome(success)
+		if c.failureRateExceeded() {
+			c.transition(CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		c.probesInFlight--
+		if !success {
+			c.transition(CircuitOpen)
+			break
+		}
+		c.probeSuccesses++
+		if c.probeSuccesses >= c.options.HalfOpenCalls {
+			c.transition(CircuitClosed)
+		}
+	}
+}
+
+func (c *CircuitBreaker) recordOutcome(success bool) {
+	if len(c.outcomes) < c.options.WindowSize {
+		c.outcomes = append(c.outcomes, success)
+		return
+	}
+	c.outcomes[c.next] = success
+	c.next = (c.next + 1) % c.options.WindowSize
+}
+
+func (c *CircuitBreaker) failureRateExceeded() bool {
+	if len(c.outcomes) < c.options.MinimumCalls {
+		return false
+	}
+	failures := 0
+	for _, success := range c.outcomes {
+		if !success {
+			failures++
+		}
+	}
+	rate := float64(failures) / float64(len(c.outcomes))
+	logger(Verbose, c, "Failure rate %.2f over %d calls.", rate, len(c.outcomes))
+	return rate >= c.options.FailureThreshold
+}
+
+// transition must be called while holding the lock.
+func (c *CircuitBreaker) transition(to CircuitState) {
+	logger(Info, c, "Circuit breaker state change: %s -> %s", c.state, to)
+	c.state = to
+	c.generation++
+	c.probesInFlight = 0
+	c.probeSuccesses = 0
+	switch to {
+	case CircuitOpen:
+		c.openedAt = clock.Now()
+	case CircuitClosed:
+		c.outcomes = nil
+		c.next = 0
+	}
+	if c.states.started && !c.states.cancelled() {
+		c.pending = append(c.pending, to)
+		select {
+		case c.queued <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// BreakSink returns a [Sink] calling the provided sink through the provided [CircuitBreaker]. While the breaker is
+// open the returned sink fails with [CircuitOpenError] without calling the sink.
+func BreakSink[T any](breaker *CircuitBreaker, sink func(T) error) Sink[T] {
+	return func(item T) error {
+		return breaker.Execute(func() error {
+			return sink(item)
+		})
+	}
+}
+
+// BreakGenerator returns a generator function calling the provided generator through the provided [CircuitBreaker].
+// While the breaker is open the returned generator fails with [CircuitOpenError] without calling the generator, so
+// the generator source's backoff applies. Empty polls and [GeneratorFinished] count as successes.
+func BreakGenerator[T any](breaker *CircuitBreaker, generator func() (*T, error)) func() (*T, error) {
+	return func() (*T, error) {
+		generation, err := breaker.acquire()
+		if err != nil {
+			return nil, err
+		}
+		item, err := generator()
+		var finished *GeneratorFinished
+		breaker.record(generation, err == nil || errors.As(err, &finished))
+		return item, err
+	}
+}
@@ -0,0 +1,225 @@
+package reactive
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensOnFailureRate(t *testing.T) {
+	underTest := NewCircuitBreaker(CircuitBreakerOptions{WindowSize: 4, FailureThreshold: 0.5, CoolDown: time.Hour})
+	calls := 0
+	sink := BreakSink(underTest, func(item bool) error {
+		calls++
+		if item {
+			return nil
+		}
+		return errors.New("test error")
+	})
+	assert.NoError(t, sink(true))
+	assert.NoError(t, sink(true))
+	assert.Error(t, sink(false))
+	assert.Equal(t, CircuitClosed, underTest.State())
+	assert.Error(t, sink(false))
+	assert.Equal(t, CircuitOpen, underTest.State())
+	var openError *CircuitOpenError
+	assert.ErrorAs(t, sink(true), &openError)
+	assert.Equal(t, 4, calls)
+	assert.NotEmpty(t, openError.Error())
+}
+
+func TestCircuitBreaker_HalfOpenProbes(t *testing.T) {
+	fake := useFakeClock(t)
+	underTest := NewCircuitBreaker(CircuitBreakerOptions{
+		WindowSize:    1,
+		CoolDown:      10 * time.Millisecond,
+		HalfOpenCalls: 2,
+	})
+	fail := errors.New("test error")
+	assert.ErrorIs(t, underTest.Execute(func() error { return fail }), fail)
+	assert.Equal(t, CircuitOpen, underTest.State())
+	fake.Advance(9 * time.Millisecond)
+	var openError *CircuitOpenError
+	assert.ErrorAs(t, underTest.Execute(func() error { return nil }), &openError)
+	fake.Advance(time.Millisecond)
+	assert.ErrorIs(t, underTest.Execute(func() error { return fail }), fail)
+	assert.Equal(t, CircuitOpen, underTest.State())
+	fake.Advance(10 * time.Millisecond)
+	assert.NoError(t, underTest.Execute(func() error { return nil }))
+	assert.Equal(t, CircuitHalfOpen, underTest.State())
+	assert.NoError(t, underTest.Execute(func() error { return nil }))
+	assert.Equal(t, CircuitClosed, underTest.State())
+}
+
+func TestCircuitBreaker_LimitsConcurrentProbes(t *testing.T) {
+	fake := useFakeClock(t)
+	underTest := NewCircuitBreaker(CircuitBreakerOptions{WindowSize: 1, CoolDown: time.Millisecond})
+	_ = underTest.Execute(func() error { return errors.New("test error") })
+	fake.Advance(time.Millisecond)
+	var openError *CircuitOpenError
+	assert.NoError(t, underTest.Execute(func() error {
+		assert.ErrorAs(t, underTest.Execute(func() error { return nil }), &openError)
+		return nil
+	}))
+	assert.Equal(t, CircuitClosed, underTest.State())
+}
+
+func TestCircuitBreaker_CountsPanicsAsFailures(t *testing.T) {
+	underTest := NewCircuitBreaker(CircuitBreakerOptions{WindowSize: 1})
+	assert.Panics(t, func() {
+		_ = underTest.Execute(func() error { panic("test panic") })
+	})
+	assert.Equal(t, CircuitOpen, underTest.State())
+}
+
+func TestCircuitBreaker_IgnoresCallsAdmittedBeforeStateChange(t *testing.T) {
+	fake := useFakeClock(t)
+	underTest := NewCircuitBreaker(CircuitBreakerOptions{WindowSize: 2, HalfOpenCalls: 1, CoolDown: time.Second})
+	fail := errors.New("test error")
+	slowStarted, releaseSlow, slowDone := make(chan struct{}), make(chan struct{}), make(chan error)
+	go func() {
+		slowDone <- underTest.Execute(func() error {
+			close(slowStarted)
+			<-releaseSlow
+			return nil
+		})
+	}()
+	<-slowStarted
+	assert.ErrorIs(t, underTest.Execute(func() error { return fail }), fail)
+	assert.ErrorIs(t, underTest.Execute(func() error { return fail }), fail)
+	assert.Equal(t, CircuitOpen, underTest.State())
+	fake.Advance(time.Second)
+	probeStarted, releaseProbe, probeDone := make(chan struct{}), make(chan struct{}), make(chan error)
+	go func() {
+		probeDone <- underTest.Execute(func() error {
+			close(probeStarted)
+			<-releaseProbe
+			return fail
+		})
+	}()
+	<-probeStarted
+	close(releaseSlow)
+	assert.NoError(t, <-slowDone)
+	assert.Equal(t, CircuitHalfOpen, underTest.State())
+	close(releaseProbe)
+	assert.ErrorIs(t, <-probeDone, fail)
+	assert.Equal(t, CircuitOpen, underTest.State())
+}
+
+func TestCircuitBreaker_OnlyQueuesStatesOnceStarted(t *testing.T) {
+	fake := useFakeClock(t)
+	underTest := NewCircuitBreaker(CircuitBreakerOptions{WindowSize: 1, CoolDown: time.Second})
+	for range 10 {
+		_ = underTest.Execute(func() error { return errors.New("test error") })
+		fake.Advance(time.Second)
+		_ = underTest.Execute(func() error { return nil })
+	}
+	underTest.lock.Lock()
+	assert.Empty(t, underTest.pending)
+	underTest.lock.Unlock()
+}
+
+func TestCircuitBreaker_States(t *testing.T) {
+	fake := useFakeClock(t)
+	underTest := NewCircuitBreaker(CircuitBreakerOptions{WindowSize: 1, CoolDown: time.Millisecond})
+	states := underTest.States()
+	var results []CircuitState
+	states.Observe(func(state CircuitState) error {
+		results = append(results, state)
+		return nil
+	})
+	states.Start()
+	_ = underTest.Execute(func() error { return errors.New("test error") })
+	fake.Advance(time.Millisecond)
+	_ = underTest.Execute(func() error { return nil })
+	assert.NoError(t, states.Cancel())
+	states.AwaitCompletion()
+	assert.Equal(t, []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}, results)
+}
+
+func TestCircuitBreaker_StatesInOrderUnderConcurrency(t *testing.T) {
+	fakeClock := useFakeClock(t)
+	underTest := NewCircuitBreaker(CircuitBreakerOptions{WindowSize: 1, CoolDown: time.Second})
+	states := underTest.States()
+	var results []CircuitState
+	states.Observe(func(state CircuitState) error {
+		results = append(results, state)
+		return nil
+	})
+	states.Start()
+	var wg sync.WaitGroup
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for call := 0; call < 200; call++ {
+				_ = underTest.Execute(func() error {
+					if (call+worker)%2 == 0 {
+						return errors.New("test error")
+					}
+					return nil
+				})
+				fakeClock.Advance(time.Second)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.NoError(t, states.Cancel())
+	states.AwaitCompletion()
+	valid := map[CircuitState][]CircuitState{
+		CircuitClosed:   {CircuitOpen},
+		CircuitOpen:     {CircuitHalfOpen},
+		CircuitHalfOpen: {CircuitOpen, CircuitClosed},
+	}
+	from := CircuitClosed
+	for _, to := range results {
+		assert.Contains(t, valid[from], to, "%s -> %s", from, to)
+		from = to
+	}
+	assert.Equal(t, underTest.State(), from)
+}
+
+func TestBreakGenerator_BacksOffWhileOpen(t *testing.T) {
+	useFakeClock(t)
+	breaker := NewCircuitBreaker(CircuitBreakerOptions{WindowSize: 2, CoolDown: time.Hour})
+	calls := 0
+	generator := BreakGenerator(breaker, func() (*int, error) {
+		calls++
+		if calls > 2 {
+			return nil, &GeneratorFinished{}
+		}
+		return nil, errors.New("test error")
+	})
+	polls := 0
+	var underTest CancellableSource[int]
+	underTest = FromGenerator(func() (*int, error) {
+		polls++
+		if polls == 10 {
+			assert.NoError(t, underTest.Cancel())
+		}
+		return generator()
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, CircuitOpen, breaker.State())
+}
+
+func TestBreakGenerator_FinishedIsSuccess(t *testing.T) {
+	breaker := NewCircuitBreaker(CircuitBreakerOptions{WindowSize: 1})
+	underTest := FromGenerator(BreakGenerator(breaker, func() (*int, error) {
+		return nil, &GeneratorFinished{}
+	}))
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitState_String(t *testing.T) {
+	assert.Equal(t, "Closed", CircuitClosed.String())
+	assert.Equal(t, "Open", CircuitOpen.String())
+	assert.Equal(t, "HalfOpen", CircuitHalfOpen.String())
+	assert.Equal(t, "42", CircuitState(42).String())
+}
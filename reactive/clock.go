@@ -0,0 +1,51 @@
+package reactive
+
+import (
+	"time"
+)
+
+// Clock is the source of time for every time based operation in the reactive package: backoffs, batching, windows
+// and rate limits. Use SetClock to replace it, for example with a fake clock in unit tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// AfterFunc waits for the duration to elapse and then calls f.
+	AfterFunc(d time.Duration, f func()) Stopper
+}
+
+// Stopper is a pending call scheduled via [Clock.AfterFunc].
+type Stopper interface {
+	// Stop prevents the call from running. It returns false if the call already ran or was already stopped.
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Stopper {
+	return time.AfterFunc(d, f)
+}
+
+var clock Clock = realClock{}
+
+// SetClock sets the [Clock] for all time based operations in the reactive package. The default clock uses the time
+// package. Passing nil restores the default clock.
+func SetClock(newClock Clock) {
+	if newClock == nil {
+		newClock = realClock{}
+	}
+	clock = newClock
+}
+
+func sleep(d time.Duration) {
+	<-clock.After(d)
+}
@@ -0,0 +1,131 @@
+package reactive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a [Clock] that only moves when advanced. Timers due after an advance run on the advancing go routine.
+type fakeClock struct {
+	lock    sync.Mutex
+	now     time.Time
+	waiters []*fakeTimer
+	slept   time.Duration
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	fire  func(time.Time)
+}
+
+func useFakeClock(t *testing.T) *fakeClock {
+	ret := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	SetClock(ret)
+	t.Cleanup(func() {
+		SetClock(nil)
+	})
+	return ret
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	c := make(chan time.Time, 1)
+	f.schedule(d, func(now time.Time) {
+		c <- now
+	})
+	return c
+}
+
+func (f *fakeClock) AfterFunc(d time.Duration, fn func()) Stopper {
+	return f.schedule(d, func(time.Time) {
+		fn()
+	})
+}
+
+func (f *fakeClock) schedule(d time.Duration, fire func(time.Time)) *fakeTimer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	timer := &fakeTimer{clock: f, at: f.now.Add(d), fire: fire}
+	f.waiters = append(f.waiters, timer)
+	return timer
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+	index := slices.Index(t.clock.waiters, t)
+	if index < 0 {
+		return false
+	}
+	t.clock.waiters = slices.Delete(t.clock.waiters, index, index+1)
+	return true
+}
+
+// Advance moves the clock forward, running every timer that becomes due in order.
+func (f *fakeClock) Advance(d time.Duration) {
+	f.lock.Lock()
+	target := f.now.Add(d)
+	f.lock.Unlock()
+	for {
+		f.lock.Lock()
+		slices.SortStableFunc(f.waiters, func(a *fakeTimer, b *fakeTimer) int {
+			return a.at.Compare(b.at)
+		})
+		if len(f.waiters) == 0 || f.waiters[0].at.After(target) {
+			f.now = target
+			f.lock.Unlock()
+			return
+		}
+		next := f.waiters[0]
+		f.waiters = f.waiters[1:]
+		if next.at.After(f.now) {
+			f.now = next.at
+		}
+		now := f.now
+		f.lock.Unlock()
+		next.fire(now)
+	}
+}
+
+// AwaitWaiters blocks until at least count timers are pending.
+func (f *fakeClock) AwaitWaiters(count int) {
+	for {
+		f.lock.Lock()
+		pending := len(f.waiters)
+		f.lock.Unlock()
+		if pending >= count {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSetClock_NilRestoresDefault(t *testing.T) {
+	useFakeClock(t)
+	SetClock(nil)
+	assert.IsType(t, realClock{}, clock)
+	assert.WithinDuration(t, time.Now(), clock.Now(), time.Second)
+	assert.True(t, clock.AfterFunc(time.Hour, func() {}).Stop())
+	<-clock.After(time.Millisecond)
+}
+
+func TestFakeClock_RunsTimersInOrder(t *testing.T) {
+	underTest := useFakeClock(t)
+	var fired []int
+	underTest.AfterFunc(2*time.Second, func() { fired = append(fired, 2) })
+	underTest.AfterFunc(time.Second, func() { fired = append(fired, 1) })
+	stopped := underTest.AfterFunc(time.Second, func() { fired = append(fired, 3) })
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+	underTest.Advance(3 * time.Second)
+	assert.Equal(t, []int{1, 2}, fired)
+}
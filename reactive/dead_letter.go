@@ -0,0 +1,126 @@
+package reactive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Failed describes an item a sink failed to handle.
+type Failed[T any] struct {
+	// Item is the item that failed.
+	Item T
+	// Err is the error returned by the sink, or an error describing its panic.
+	Err error
+	// Sink identifies the failing sink.
+	Sink string
+	// Attempts is the number of times the sink was called with the item. See [RetryError].
+	Attempts int
+	// Time is when the failure was observed.
+	Time time.Time
+}
+
+// MarshalJSON encodes the failure with its error as a string, empty if Err is nil.
+func (f Failed[T]) MarshalJSON() ([]byte, error) {
+	message := ""
+	if f.Err != nil {
+		message = f.Err.Error()
+	}
+	return json.Marshal(struct {
+		Item     T         `json:"item"`
+		Err      string    `json:"error"`
+		Sink     string    `json:"sink"`
+		Attempts int       `json:"attempts"`
+		Time     time.Time `json:"time"`
+	}{f.Item, message, f.Sink, f.Attempts, f.Time})
+}
+
+// WithDeadLetter routes every item a sink of the provided [Source] fails to handle (by returning an error or
+// panicking) to the dead letter sink, wrapped in [Failed]. Mapper errors and panics of [Map], [MapConcurrent] and
+// [MapWithRetry] are routed too. Returns the provided Source.
+//
+// Only Sources created by this package support dead letters; other Sources are returned unchanged.
+func WithDeadLetter[T any](source Source[T], dlq Sink[Failed[T]]) Source[T] {
+	routable, ok := source.(interface {
+		routeDeadLetters(func(Failed[T]) error)
+	})
+	if !ok {
+		logger(Warning, source, "Source does not support dead letters.")
+		return source
+	}
+	routable.routeDeadLetters(dlq)
+	return source
+}
+
+func (b *baseSource[T]) routeDeadLetters(dlq func(Failed[T]) error) {
+	b.log(Debug, "Registering dead letter sink (%p)", dlq)
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.deadLetters = append(b.deadLetters, dlq)
+}
+
+func (b *baseSource[T]) deadLetter(item T, sink interface{}, err error) {
+	if len(b.deadLetters) == 0 {
+		return
+	}
+	failed := Failed[T]{
+		Item:     item,
+		Err:      err,
+		Sink:     fmt.Sprintf("%p", sink),
+		Attempts: 1,
+		Time:     clock.Now(),
+	}
+	var retryError *RetryError
+	if errors.As(err, &retryError) {
+		failed.Attempts = retryError.Attempts
+	}
+	for _, dlq := range b.deadLetters {
+		b.sendDeadLetter(failed, dlq)
+	}
+}
+
+func (b *baseSource[T]) sendDeadLetter(failed Failed[T], dlq func(Failed[T]) error) {
+	defer b.logPanic(dlq)
+	b.log(Verbose, "Sending item (%.10v) to dead letter sink (%p)", failed.Item, dlq)
+	if err := dlq(failed); err != nil {
+		b.log(Error, "Failed to write item (%.10v) to dead letter sink (%p): [%s]", failed.Item, dlq, err)
+	}
+}
+
+// JSONLinesDeadLetters returns a dead letter [Sink] writing each [Failed] item to the provided writer as one line of
+// JSON. Writes are serialized, so the sink is safe to share between Sources.
+func JSONLinesDeadLetters[T any](w io.Writer) Sink[Failed[T]] {
+	lock := sync.Mutex{}
+	encoder := json.NewEncoder(w)
+	return func(failed Failed[T]) error {
+		lock.Lock()
+		defer lock.Unlock()
+		return encoder.Encode(failed)
+	}
+}
+
+// MemoryDeadLetters collects [Failed] items in memory. It is intended for inspecting failures in tests.
+type MemoryDeadLetters[T any] struct {
+	lock   sync.Mutex
+	failed []Failed[T]
+}
+
+// Sink returns a dead letter [Sink] collecting into this MemoryDeadLetters.
+func (m *MemoryDeadLetters[T]) Sink() Sink[Failed[T]] {
+	return func(failed Failed[T]) error {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		m.failed = append(m.failed, failed)
+		return nil
+	}
+}
+
+// Items returns a copy of the collected failures.
+func (m *MemoryDeadLetters[T]) Items() []Failed[T] {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]Failed[T](nil), m.failed...)
+}
@@ -0,0 +1,150 @@
+package reactive
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWithDeadLetter_CollectsSinkFailures(t *testing.T) {
+	dlq := MemoryDeadLetters[string]{}
+	underTest := WithDeadLetter(Just("foobar", "test"), dlq.Sink())
+	underTest.Observe(func(item string) error {
+		if item == "test" {
+			return errors.New("test error")
+		}
+		return nil
+	})
+	underTest.Observe(func(item string) error {
+		if item == "foobar" {
+			panic("test panic")
+		}
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	failed := dlq.Items()
+	assert.Len(t, failed, 2)
+	assert.Equal(t, "foobar", failed[0].Item)
+	assert.ErrorContains(t, failed[0].Err, "test panic")
+	assert.Equal(t, "test", failed[1].Item)
+	assert.ErrorContains(t, failed[1].Err, "test error")
+	assert.NotEqual(t, failed[0].Sink, failed[1].Sink)
+	assert.Equal(t, 1, failed[1].Attempts)
+	assert.False(t, failed[1].Time.IsZero())
+}
+
+func TestWithDeadLetter_RecordsAttemptsAndMapperFailures(t *testing.T) {
+	dlq := MemoryDeadLetters[int]{}
+	source := WithDeadLetter(Just(1), dlq.Sink())
+	MapWithRetry(source, func(item int) (string, error) {
+		return "", errors.New("test error")
+	}, RetryPolicy{MaxAttempts: 3})
+	source.Start()
+	source.AwaitCompletion()
+	failed := dlq.Items()
+	assert.Len(t, failed, 1)
+	assert.Equal(t, 1, failed[0].Item)
+	assert.Equal(t, 3, failed[0].Attempts)
+}
+
+func TestWithDeadLetter_RecordsMapperPanics(t *testing.T) {
+	dlq := MemoryDeadLetters[int]{}
+	source := WithDeadLetter(Just(1, 2), dlq.Sink())
+	var results []int
+	Map(source, func(item int) (int, error) {
+		if item == 1 {
+			panic("test panic")
+		}
+		return item, nil
+	}).Observe(func(item int) error {
+		results = append(results, item)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	failed := dlq.Items()
+	assert.Len(t, failed, 1)
+	assert.Equal(t, 1, failed[0].Item)
+	assert.ErrorContains(t, failed[0].Err, "test panic")
+	assert.Equal(t, []int{2}, results)
+}
+
+func TestWithDeadLetter_RecordsConcurrentMapperFailures(t *testing.T) {
+	for _, ordered := range []bool{true, false} {
+		dlq := MemoryDeadLetters[int]{}
+		source := WithDeadLetter(Just(1, 2, 3), dlq.Sink())
+		MapConcurrent(source, func(item int) (int, error) {
+			switch item {
+			case 1:
+				panic("test panic")
+			case 2:
+				return 0, errors.New("test error")
+			}
+			return item, nil
+		}, 2, ordered)
+		source.Start()
+		source.AwaitCompletion()
+		failed := dlq.Items()
+		assert.Len(t, failed, 2)
+		sort.Slice(failed, func(i, j int) bool {
+			return failed[i].Item < failed[j].Item
+		})
+		assert.ErrorContains(t, failed[0].Err, "test panic")
+		assert.ErrorContains(t, failed[1].Err, "test error")
+	}
+}
+
+func TestWithDeadLetter_HandlesDeadLetterFailures(t *testing.T) {
+	underTest := WithDeadLetter(Just("test"), func(Failed[string]) error {
+		return errors.New("dead letter error")
+	})
+	WithDeadLetter(underTest, func(Failed[string]) error {
+		panic("dead letter panic")
+	})
+	underTest.Observe(func(string) error {
+		return errors.New("test error")
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+}
+
+type foreignSource[T any] struct {
+	Source[T]
+}
+
+func TestWithDeadLetter_UnsupportedSource(t *testing.T) {
+	source := foreignSource[string]{Just("test")}
+	assert.Equal(t, source, WithDeadLetter[string](source, func(Failed[string]) error {
+		return nil
+	}))
+}
+
+func TestJSONLinesDeadLetters_WritesLines(t *testing.T) {
+	buffer := bytes.Buffer{}
+	underTest := WithDeadLetter(Just("foobar", "test"), JSONLinesDeadLetters[string](&buffer))
+	underTest.Observe(func(item string) error {
+		return errors.New("test error")
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Len(t, lines, 2)
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, "foobar", decoded["item"])
+	assert.Equal(t, "test error", decoded["error"])
+	assert.Equal(t, float64(1), decoded["attempts"])
+	assert.NotEmpty(t, decoded["sink"])
+	assert.NotEmpty(t, decoded["time"])
+}
+
+func TestFailed_MarshalsNilError(t *testing.T) {
+	encoded, err := json.Marshal(Failed[string]{Item: "test"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"error":""`)
+}
@@ -0,0 +1,223 @@
+package reactive
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// FromJSONLines returns a [CancellableSource] of the values decoded from each line of JSON read from the provided
+// reader. Blank lines are skipped. A malformed line is reported through the generator error path, with its line
+// number, and the source moves on to the next line. The source completes at the end of the reader.
+func FromJSONLines[T any](r io.Reader) CancellableSource[T] {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	return FromGenerator(func() (*T, error) {
+		for scanner.Scan() {
+			line++
+			if len(scanner.Bytes()) == 0 {
+				continue
+			}
+			var item T
+			if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			return &item, nil
+		}
+		return nil, finishedAfter(scanner.Err(), "line", line)
+	})
+}
+
+// FromCSV returns a [CancellableSource] of the records read from the provided CSV reader. Each record is a map of
+// column name to value. When hasHeader is true the first record names the columns; otherwise columns are named by
+// their index, starting at "0". A malformed record is reported through the generator error path, with its line
+// number, and the source moves on to the next record. A header that cannot be read completes the source with an
+// error.
+func FromCSV(r io.Reader, hasHeader bool) CancellableSource[map[string]string] {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	var header []string
+	return FromGenerator(func() (*map[string]string, error) {
+		record, line, err := readCSV(reader)
+		if err != nil && hasHeader && header == nil {
+			return nil, headerError(err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hasHeader && header == nil {
+			header = record
+			return nil, nil
+		}
+		if header != nil && len(record) != len(header) {
+			return nil, fmt.Errorf("line %d: expected %d fields, found %d", line, len(header), len(record))
+		}
+		ret := make(map[string]string, len(record))
+		for index, value := range record {
+			if header != nil {
+				ret[header[index]] = value
+			} else {
+				ret[strconv.Itoa(index)] = value
+			}
+		}
+		return &ret, nil
+	})
+}
+
+// FromCSVRecords is similar to [FromCSV], but maps each record onto a struct of type T. The first record must be a
+// header. Exported fields are matched to columns by their `csv` tag, or by their name when untagged; a tag of "-"
+// skips the field. String, bool, integer and floating point fields are supported; empty values leave the field at
+// its zero value. A record that cannot be mapped is reported through the generator error path, with its line number.
+// A header that cannot be read completes the source with an error. FromCSVRecords panics if T is not a struct.
+func FromCSVRecords[T any](r io.Reader) CancellableSource[T] {
+	structType := reflect.TypeFor[T]()
+	if structType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("reactive: FromCSVRecords type must be a struct, found %s", structType))
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	var columns []int
+	return FromGenerator(func() (*T, error) {
+		record, line, err := readCSV(reader)
+		if err != nil && columns == nil {
+			return nil, headerError(err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if columns == nil {
+			columns = csvColumns(structType, record)
+			return nil, nil
+		}
+		var item T
+		value := reflect.ValueOf(&item).Elem()
+		for index, field := range columns {
+			if field < 0 || index >= len(record) {
+				continue
+			}
+			if err := setField(value.Field(field), record[index]); err != nil {
+				return nil, fmt.Errorf("line %d, column %d: %w", line, index+1, err)
+			}
+		}
+		return &item, nil
+	})
+}
+
+// csvColumns maps each header column to the index of its struct field, or -1.
+func csvColumns(structType reflect.Type, header []string) []int {
+	ret := make([]int, len(header))
+	for index, name := range header {
+		ret[index] = -1
+		for field := range structType.NumField() {
+			candidate := structType.Field(field)
+			tag := candidate.Tag.Get("csv")
+			if !candidate.IsExported() || tag == "-" {
+				continue
+			}
+			if tag == name || (tag == "" && candidate.Name == name) {
+				ret[index] = field
+				break
+			}
+		}
+	}
+	return ret
+}
+
+func setField(field reflect.Value, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// FromGob returns a [CancellableSource] of the values decoded from the gob stream read from the provided reader. A
+// gob stream cannot be resynchronized after a decoding error, so a malformed value is reported, with its record
+// number, and completes the source.
+func FromGob[T any](r io.Reader) CancellableSource[T] {
+	decoder := gob.NewDecoder(r)
+	record := 0
+	return FromGenerator(func() (*T, error) {
+		record++
+		var item T
+		err := decoder.Decode(&item)
+		if errors.Is(err, io.EOF) {
+			return nil, &GeneratorFinished{}
+		}
+		if err != nil {
+			return nil, finishedAfter(err, "record", record)
+		}
+		return &item, nil
+	})
+}
+
+// finishedAfter returns GeneratorFinished, joined with the provided error annotated with its position.
+func finishedAfter(err error, unit string, position int) error {
+	if err == nil {
+		return &GeneratorFinished{}
+	}
+	return errors.Join(fmt.Errorf("%s %d: %w", unit, position, err), &GeneratorFinished{})
+}
+
+// headerError completes the source when the header record could not be read, as later records cannot be mapped.
+func headerError(err error) error {
+	var finished *GeneratorFinished
+	if errors.As(err, &finished) {
+		return err
+	}
+	return errors.Join(fmt.Errorf("header: %w", err), &GeneratorFinished{})
+}
+
+// readCSV reads the next record. Parse errors are returned so the record is skipped; any other error completes the
+// source.
+func readCSV(reader *csv.Reader) ([]string, int, error) {
+	record, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, 0, &GeneratorFinished{}
+	}
+	var parseError *csv.ParseError
+	if err != nil && !errors.As(err, &parseError) {
+		line, _ := reader.FieldPos(0)
+		return nil, 0, finishedAfter(err, "line", line)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	line, _ := reader.FieldPos(0)
+	return record, line, nil
+}
@@ -0,0 +1,172 @@
+package reactive
+
+import (
+	"bytes"
+	"encoding/gob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+type decoded struct {
+	Name    string  `json:"name" csv:"name"`
+	Count   int     `json:"count" csv:"count"`
+	Ratio   float64 `csv:"ratio"`
+	Enabled bool
+	Size    uint8
+	Skipped string `csv:"-"`
+}
+
+func TestFromJSONLines_SkipsMalformedLines(t *testing.T) {
+	messages := captureLogs(t)
+	underTest := FromJSONLines[decoded](strings.NewReader(
+		"{\"name\":\"foobar\",\"count\":1}\n\n{not json}\n{\"name\":\"test\",\"count\":2}\n",
+	))
+	var results []decoded
+	underTest.Observe(func(item decoded) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []decoded{{Name: "foobar", Count: 1}, {Name: "test", Count: 2}}, results)
+	checkForLog(t, messages(), Info, "line 3:")
+}
+
+func TestFromJSONLines_ReportsReadErrors(t *testing.T) {
+	messages := captureLogs(t)
+	underTest := FromJSONLines[decoded](iotest.ErrReader(assert.AnError))
+	underTest.Start()
+	underTest.AwaitCompletion()
+	checkForLog(t, messages(), Info, "completion with error")
+}
+
+func TestFromCSV_WithHeader(t *testing.T) {
+	messages := captureLogs(t)
+	underTest := FromCSV(strings.NewReader("name,count\nfoobar,1\ntest\n\"bad,2\nfizzbuzz,3\n"), true)
+	var results []map[string]string
+	underTest.Observe(func(item map[string]string) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []map[string]string{{"name": "foobar", "count": "1"}}, results)
+	checkForLog(t, messages(), Info, "line 3: expected 2 fields, found 1")
+	checkForLog(t, messages(), Info, "line 4")
+}
+
+func TestFromCSV_WithoutHeader(t *testing.T) {
+	underTest := FromCSV(strings.NewReader("foobar,1\ntest\n"), false)
+	var results []map[string]string
+	underTest.Observe(func(item map[string]string) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []map[string]string{{"0": "foobar", "1": "1"}, {"0": "test"}}, results)
+}
+
+func TestFromCSV_CompletesOnReadError(t *testing.T) {
+	underTest := FromCSV(iotest.ErrReader(assert.AnError), false)
+	underTest.Start()
+	underTest.AwaitCompletion()
+}
+
+func TestFromCSVRecords_MapsStructs(t *testing.T) {
+	messages := captureLogs(t)
+	underTest := FromCSVRecords[decoded](strings.NewReader(
+		"name,count,ratio,Enabled,Size,Skipped,unknown\n" +
+			"foobar,1,0.5,true,7,no,x\n" +
+			"test,two,0.5,true,7,no,x\n" +
+			"fizzbuzz,3\n",
+	))
+	var results []decoded
+	underTest.Observe(func(item decoded) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []decoded{
+		{Name: "foobar", Count: 1, Ratio: 0.5, Enabled: true, Size: 7},
+		{Name: "fizzbuzz", Count: 3},
+	}, results)
+	checkForLog(t, messages(), Info, "line 3, column 2")
+}
+
+func TestFromCSVRecords_ReportsBadValues(t *testing.T) {
+	type unsupported struct {
+		Enabled bool
+		Size    uint8
+		Ratio   float32
+		Tags    []string
+	}
+	messages := captureLogs(t)
+	underTest := FromCSVRecords[unsupported](strings.NewReader(
+		"Enabled,Size,Ratio,Tags\nmaybe\n,300\n,,x\n,,,a\n",
+	))
+	underTest.Start()
+	underTest.AwaitCompletion()
+	checkForLog(t, messages(), Info, "line 2, column 1")
+	checkForLog(t, messages(), Info, "line 3, column 2")
+	checkForLog(t, messages(), Info, "line 4, column 3")
+	checkForLog(t, messages(), Info, "unsupported field type")
+}
+
+func TestFromCSVRecords_FinishesOnBadHeader(t *testing.T) {
+	messages := captureLogs(t)
+	underTest := FromCSVRecords[decoded](strings.NewReader("name,\"count\n" + "foobar,1\n"))
+	underTest.Observe(func(item decoded) error {
+		t.Fail()
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	checkForLog(t, messages(), Info, "completion with error: [header: ")
+}
+
+func TestFromCSV_FinishesOnBadHeader(t *testing.T) {
+	messages := captureLogs(t)
+	underTest := FromCSV(strings.NewReader("name,\"count\n"+"foobar,1\n"), true)
+	underTest.Observe(func(item map[string]string) error {
+		t.Fail()
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	checkForLog(t, messages(), Info, "completion with error: [header: ")
+}
+
+func TestFromCSVRecords_RejectsNonStructs(t *testing.T) {
+	assert.PanicsWithValue(t, "reactive: FromCSVRecords type must be a struct, found string", func() {
+		FromCSVRecords[string](strings.NewReader("name\n"))
+	})
+}
+
+func TestFromGob_HappyPath(t *testing.T) {
+	buffer := bytes.Buffer{}
+	encoder := gob.NewEncoder(&buffer)
+	require.NoError(t, encoder.Encode(decoded{Name: "foobar", Count: 1}))
+	require.NoError(t, encoder.Encode(decoded{Name: "test", Count: 2}))
+	underTest := FromGob[decoded](&buffer)
+	var results []decoded
+	underTest.Observe(func(item decoded) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []decoded{{Name: "foobar", Count: 1}, {Name: "test", Count: 2}}, results)
+}
+
+func TestFromGob_ReportsMalformedRecords(t *testing.T) {
+	messages := captureLogs(t)
+	underTest := FromGob[decoded](strings.NewReader("not gob"))
+	underTest.Start()
+	underTest.AwaitCompletion()
+	checkForLog(t, messages(), Info, "record 1:")
+}
@@ -0,0 +1,103 @@
+package reactive
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Encoder writes encoded values to an underlying writer. [json.Encoder] and [gob.Encoder] are Encoders.
+type Encoder interface {
+	Encode(v any) error
+}
+
+// EncoderSink serializes items to a writer through an [Encoder]. Writes are buffered and serialized with a lock, so
+// one EncoderSink may observe several Sources, whose sinks run concurrently. Buffered data is written by Flush, which
+// [EncoderSink.Attach] arranges to happen when the observed Source closes.
+type EncoderSink[T any] struct {
+	lock    sync.Mutex
+	writer  *bufio.Writer
+	encoder Encoder
+}
+
+// NewEncoderSink returns an [EncoderSink] writing to w through the encoder returned by newEncoder. The encoder is
+// given a buffered writer wrapping w.
+func NewEncoderSink[T any](w io.Writer, newEncoder func(io.Writer) Encoder) *EncoderSink[T] {
+	writer := bufio.NewWriter(w)
+	return &EncoderSink[T]{
+		writer:  writer,
+		encoder: newEncoder(writer),
+	}
+}
+
+// JSONLinesSink returns an [EncoderSink] writing each item to w as one line of JSON.
+func JSONLinesSink[T any](w io.Writer) *EncoderSink[T] {
+	return NewEncoderSink[T](w, func(writer io.Writer) Encoder {
+		return json.NewEncoder(writer)
+	})
+}
+
+// GobSink returns an [EncoderSink] writing items to w as a gob stream. See [FromGob].
+func GobSink[T any](w io.Writer) *EncoderSink[T] {
+	return NewEncoderSink[T](w, func(writer io.Writer) Encoder {
+		return gob.NewEncoder(writer)
+	})
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (c *csvEncoder) Encode(v any) error {
+	record, ok := v.([]string)
+	if !ok {
+		return fmt.Errorf("expected a []string record, found %T", v)
+	}
+	if err := c.writer.Write(record); err != nil {
+		return err
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// CSVSink returns an [EncoderSink] writing each record to w as a line of CSV. A non nil header is written first; a
+// failure to write it is logged, and reported again by the first Write or Flush.
+func CSVSink(w io.Writer, header []string) *EncoderSink[[]string] {
+	ret := NewEncoderSink[[]string](w, func(writer io.Writer) Encoder {
+		return &csvEncoder{writer: csv.NewWriter(writer)}
+	})
+	if header != nil {
+		if err := ret.Write(header); err != nil {
+			logger(Warning, ret, "Failed to write CSV header: [%v]", err)
+		}
+	}
+	return ret
+}
+
+// Write encodes the item into the buffer. It may be passed to [Source.Observe].
+func (e *EncoderSink[T]) Write(item T) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.encoder.Encode(item)
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (e *EncoderSink[T]) Flush() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.writer.Flush()
+}
+
+// Attach observes the provided [Source] with Write and flushes once the Source closes. A failed flush is logged.
+func (e *EncoderSink[T]) Attach(source Source[T]) {
+	source.Observe(e.Write)
+	source.UponClose(func() {
+		if err := e.Flush(); err != nil {
+			logger(Warning, source, "Failed to flush encoder sink (%p): [%v]", e, err)
+		}
+	})
+}
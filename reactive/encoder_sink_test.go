@@ -0,0 +1,108 @@
+package reactive
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestJSONLinesSink_RoundTrip(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	underTest := JSONLinesSink[decoded](buffer)
+	source := Just(decoded{Name: "foobar", Count: 1}, decoded{Name: "test", Count: 2})
+	underTest.Attach(source)
+	source.Start()
+	source.AwaitCompletion()
+	decodedSource := FromJSONLines[decoded](buffer)
+	var results []decoded
+	decodedSource.Observe(func(item decoded) error {
+		results = append(results, item)
+		return nil
+	})
+	decodedSource.Start()
+	decodedSource.AwaitCompletion()
+	assert.Equal(t, []decoded{{Name: "foobar", Count: 1}, {Name: "test", Count: 2}}, results)
+}
+
+func TestJSONLinesSink_SerializesConcurrentWrites(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	underTest := JSONLinesSink[int](buffer)
+	var sources []Source[int]
+	for range 4 {
+		source := Just(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+		underTest.Attach(source)
+		sources = append(sources, source)
+	}
+	for _, source := range sources {
+		source.Start()
+	}
+	for _, source := range sources {
+		source.AwaitCompletion()
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Len(t, lines, 40)
+	for _, line := range lines {
+		assert.Regexp(t, "^[0-9]+$", line)
+	}
+}
+
+func TestJSONLinesSink_BuffersUntilFlush(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	underTest := JSONLinesSink[string](buffer)
+	assert.NoError(t, underTest.Write("foobar"))
+	assert.Empty(t, buffer.String())
+	assert.NoError(t, underTest.Flush())
+	assert.Equal(t, "\"foobar\"\n", buffer.String())
+}
+
+func TestCSVSink_WritesHeader(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	underTest := CSVSink(buffer, []string{"name", "count"})
+	source := Just([]string{"foobar", "1"}, []string{"with,comma", "2"})
+	underTest.Attach(source)
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, "name,count\nfoobar,1\n\"with,comma\",2\n", buffer.String())
+}
+
+func TestGobSink_RoundTrip(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	underTest := GobSink[decoded](buffer)
+	source := Just(decoded{Name: "foobar", Count: 1}, decoded{Name: "test", Enabled: true})
+	underTest.Attach(source)
+	source.Start()
+	source.AwaitCompletion()
+	decodedSource := FromGob[decoded](buffer)
+	var results []decoded
+	decodedSource.Observe(func(item decoded) error {
+		results = append(results, item)
+		return nil
+	})
+	decodedSource.Start()
+	decodedSource.AwaitCompletion()
+	assert.Equal(t, []decoded{{Name: "foobar", Count: 1}, {Name: "test", Enabled: true}}, results)
+}
+
+func TestEncoderSink_LogsFlushErrors(t *testing.T) {
+	messages := captureLogs(t)
+	underTest := JSONLinesSink[string](errWriter{})
+	source := Just("foobar")
+	underTest.Attach(source)
+	source.Start()
+	source.AwaitCompletion()
+	checkForLog(t, messages(), Warning, "Failed to flush")
+}
+
+func TestCSVSink_ReportsHeaderErrors(t *testing.T) {
+	messages := captureLogs(t)
+	underTest := CSVSink(errWriter{}, []string{strings.Repeat("x", 5000)})
+	checkForLog(t, messages(), Warning, "Failed to write CSV header")
+	assert.ErrorIs(t, underTest.Write([]string{"foobar"}), assert.AnError)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, assert.AnError
+}
@@ -0,0 +1,108 @@
+package reactive
+
+import (
+	"time"
+)
+
+// TumblingEventTimeWindow is similar to [TumblingWindow], but assigns items to windows by the time returned from
+// timestampFn rather than by arrival time.
+//
+// Progress is tracked with a watermark: the latest event time observed minus allowedLateness. A window is emitted
+// once the watermark reaches its end. Items arriving after all of their windows have been emitted are late; late
+// items are not placed in any window and are instead sent to the second returned [Source].
+//
+// Both returned Sources are already started. When the observed Source closes, any open windows are emitted before
+// the returned Sources close.
+func TumblingEventTimeWindow[T any](
+	source Source[T],
+	size time.Duration,
+	timestampFn func(T) time.Time,
+	allowedLateness time.Duration,
+) (Source[Window[T]], Source[T]) {
+	checkPositive("TumblingEventTimeWindow", "size", size)
+	return eventTimeWindow(source, &windower[T]{assign: slidingAssigner[T](size, size)}, timestampFn, allowedLateness)
+}
+
+// SlidingEventTimeWindow is similar to [SlidingWindow], but assigns items to windows by the time returned from
+// timestampFn rather than by arrival time. Watermarks and late items are handled as described in
+// [TumblingEventTimeWindow]. Items falling in a gap between windows, when slide is larger than size, are dropped
+// rather than reported as late.
+func SlidingEventTimeWindow[T any](
+	source Source[T],
+	size time.Duration,
+	slide time.Duration,
+	timestampFn func(T) time.Time,
+	allowedLateness time.Duration,
+) (Source[Window[T]], Source[T]) {
+	checkPositive("SlidingEventTimeWindow", "size", size)
+	checkPositive("SlidingEventTimeWindow", "slide", slide)
+	return eventTimeWindow(source, &windower[T]{assign: slidingAssigner[T](size, slide)}, timestampFn, allowedLateness)
+}
+
+// SessionEventTimeWindow is similar to [SessionWindow], but groups items by the time returned from timestampFn
+// rather than by arrival time. Out of order items that bridge two sessions merge them. Watermarks and late items are
+// handled as described in [TumblingEventTimeWindow].
+func SessionEventTimeWindow[T any](
+	source Source[T],
+	gap time.Duration,
+	timestampFn func(T) time.Time,
+	allowedLateness time.Duration,
+) (Source[Window[T]], Source[T]) {
+	checkPositive("SessionEventTimeWindow", "gap", gap)
+	return eventTimeWindow(
+		source,
+		&windower[T]{assign: sessionAssigner[T](gap), merging: true},
+		timestampFn,
+		allowedLateness,
+	)
+}
+
+func eventTimeWindow[T any](
+	source Source[T],
+	w *windower[T],
+	timestampFn func(T) time.Time,
+	allowedLateness time.Duration,
+) (Source[Window[T]], Source[T]) {
+	c := make(chan Window[T])
+	ret := fromChan(c)
+	lateChan := make(chan T)
+	late := fromChan(lateChan)
+	var watermark time.Time
+	var latest time.Time
+	w.emit = func(window Window[T]) {
+		ret.log(Verbose, "Emitting window [%s, %s) with %d items.", window.Start, window.End, len(window.Items))
+		c <- window
+	}
+	source.UponClose(func() {
+		w.flush()
+		ret.log(Debug, "Closing windowing chan (%p) and late chan (%p).", c, lateChan)
+		close(c)
+		close(lateChan)
+		ret.AwaitCompletion()
+		late.AwaitCompletion()
+	})
+	source.Observe(func(item T) error {
+		defer ret.logPanic(timestampFn)
+		timestamp := timestampFn(item)
+		switch w.add(timestamp, item, watermark) {
+		case betweenWindows:
+			ret.log(Debug, "Item (%.10v) at %s falls between windows. Dropping it.", item, timestamp)
+			return nil
+		case behindWatermark:
+			ret.log(Debug, "Item (%.10v) at %s is behind the watermark %s.", item, timestamp, watermark)
+			lateChan <- item
+			return nil
+		}
+		if timestamp.After(latest) {
+			latest = timestamp
+			watermark = latest.Add(-allowedLateness)
+			ret.log(Verbose, "Watermark advanced to %s.", watermark)
+			w.fire(watermark)
+		}
+		return nil
+	})
+	ret.log(Debug, "Created event time windowing source with late source (%s).", late)
+	ret.Start()
+	late.Start()
+	return ret, late
+}
@@ -0,0 +1,113 @@
+package reactive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type timestamped struct {
+	at   time.Time
+	name string
+}
+
+var eventBase = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func event(seconds int, name string) timestamped {
+	return timestamped{at: eventBase.Add(time.Duration(seconds) * time.Second), name: name}
+}
+
+func eventTimestamp(item timestamped) time.Time {
+	return item.at
+}
+
+func names(window Window[timestamped]) []string {
+	var ret []string
+	for _, item := range window.Items {
+		ret = append(ret, item.name)
+	}
+	return ret
+}
+
+func TestTumblingEventTimeWindow_RoutesLateItems(t *testing.T) {
+	source := Just(
+		event(1, "a"),
+		event(12, "b"),
+		event(9, "c"), // within lateness, window [0, 10) still open
+		event(24, "d"),
+		event(3, "e"), // window [0, 10) already emitted
+		event(17, "f"),
+	)
+	windows, late := TumblingEventTimeWindow(source, 10*time.Second, eventTimestamp, 5*time.Second)
+	var results [][]string
+	var lateResults []string
+	windows.Observe(func(window Window[timestamped]) error {
+		results = append(results, names(window))
+		return nil
+	})
+	late.Observe(func(item timestamped) error {
+		lateResults = append(lateResults, item.name)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, [][]string{{"a", "c"}, {"b", "f"}, {"d"}}, results)
+	assert.Equal(t, []string{"e"}, lateResults)
+}
+
+func TestSlidingEventTimeWindow_HappyPath(t *testing.T) {
+	source := Just(event(7, "a"), event(12, "b"), event(40, "c"))
+	windows, _ := SlidingEventTimeWindow(source, 10*time.Second, 5*time.Second, eventTimestamp, 0)
+	var results []Window[timestamped]
+	windows.Observe(func(window Window[timestamped]) error {
+		results = append(results, window)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Len(t, results, 5)
+	assert.Equal(t, []string{"a"}, names(results[0]))
+	assert.Equal(t, eventBase, results[0].Start)
+	assert.Equal(t, []string{"a", "b"}, names(results[1]))
+	assert.Equal(t, []string{"b"}, names(results[2]))
+	assert.Equal(t, []string{"c"}, names(results[3]))
+	assert.Equal(t, []string{"c"}, names(results[4]))
+}
+
+func TestSessionEventTimeWindow_MergesOutOfOrder(t *testing.T) {
+	source := Just(event(0, "a"), event(4, "c"), event(2, "b"), event(20, "d"), event(1, "e"))
+	windows, late := SessionEventTimeWindow(source, 3*time.Second, eventTimestamp, 3*time.Second)
+	var results [][]string
+	var lateResults []string
+	windows.Observe(func(window Window[timestamped]) error {
+		results = append(results, names(window))
+		return nil
+	})
+	late.Observe(func(item timestamped) error {
+		lateResults = append(lateResults, item.name)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, [][]string{{"a", "c", "b"}, {"d"}}, results)
+	assert.Equal(t, []string{"e"}, lateResults)
+}
+
+func TestSlidingEventTimeWindow_DropsItemsBetweenWindows(t *testing.T) {
+	source := Just(event(1, "a"), event(7, "b"), event(11, "c"))
+	windows, late := SlidingEventTimeWindow(source, 5*time.Second, 10*time.Second, eventTimestamp, 0)
+	var results [][]string
+	var lateResults []string
+	windows.Observe(func(window Window[timestamped]) error {
+		results = append(results, names(window))
+		return nil
+	})
+	late.Observe(func(item timestamped) error {
+		lateResults = append(lateResults, item.name)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, [][]string{{"a"}, {"c"}}, results)
+	assert.Empty(t, lateResults)
+}
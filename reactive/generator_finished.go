@@ -1,8 +1,8 @@
 package reactive
 
 // GeneratorFinished should be returned from a generator function when the generator completes successfully,
-// but no further items are expected.
-type GeneratorFinished struct{} //todo: should generators just return three things?
+// but no further items are expected. See [FromGenerator3] for generators that report completion separately.
+type GeneratorFinished struct{}
 
 // Error implements the error interface
 func (g *GeneratorFinished) Error() string {
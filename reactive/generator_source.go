@@ -2,28 +2,40 @@ package reactive
 
 import (
 	"errors"
-	"math"
 	"time"
 )
 
 type generatorSource[T any] struct {
-	generator func() (*T, error)
+	generator func() ([]T, error)
 	baseSource[T]
-	maxBackoff            float64
-	backoffMultiplier     float64
+	backoff               Backoff
 	consecutiveErrorCount int
+	idleBackoff           Backoff
+	consecutiveEmptyCount int
+	ack                   func(T) error
 }
 
 func (g *generatorSource[T]) start() {
-	for !g.closing {
+	for !g.closing.Load() {
 		g.log(Verbose, "Polling generator (%p).", g.generator)
-		response, err := g.generator()
-		if response != nil {
-			g.pump(*response)
+		items, err := g.generator()
+		for _, item := range items {
+			if g.closing.Load() {
+				break
+			}
+			if g.pump(item) {
+				g.acknowledge(item)
+			}
+		}
+		if len(items) > 0 {
+			g.clearEmptyCount()
 		}
 		if err != nil {
 			var t *GeneratorFinished
 			switch {
+			case errors.As(err, &t) && err != error(t):
+				g.log(Info, "Generator(%p) func indicates completion with error: [%v]", g.generator, err)
+				return
 			case errors.As(err, &t):
 				g.log(Debug, "Generator(%p) func indicates completion.", g.generator)
 				return
@@ -31,35 +43,70 @@ func (g *generatorSource[T]) start() {
 				g.consecutiveErrorCount++
 				g.log(Info, "Error from generator: [%v]", err)
 				g.log(Debug, "Error count incremented: %d", g.consecutiveErrorCount)
-				g.exponentialBackoff()
+				g.backOff()
 			}
 			continue
 		}
 		g.clearErrorCount()
+		if len(items) == 0 {
+			g.consecutiveEmptyCount++
+			g.idle()
+		}
 	}
 }
 
 func (g *generatorSource[T]) Cancel() error {
 	g.log(Info, "Cancel request received. Marking source as closed.")
-	g.closing = true
+	g.closing.Store(true)
 	return nil
 }
 
-func (g *generatorSource[T]) exponentialBackoff() {
-	if g.consecutiveErrorCount == 0 || g.maxBackoff == 0 {
+func (g *generatorSource[T]) acknowledge(item T) {
+	if g.ack == nil {
 		return
 	}
-	backOff := g.backoffMultiplier * math.Pow(2.0, float64(g.consecutiveErrorCount))
-	wait := time.Duration(min(g.maxBackoff, backOff)) * time.Millisecond
+	if err := g.ack(item); err != nil {
+		g.log(Warning, "Failed to acknowledge item (%.10v): [%v]", item, err)
+	}
+}
+
+func (g *generatorSource[T]) backOff() {
+	if g.consecutiveErrorCount == 0 || g.backoff == nil {
+		return
+	}
+	wait := g.backoff.Next(g.consecutiveErrorCount)
 
 	g.log(Verbose, "Waiting %s before next generator poll.", wait)
-	time.Sleep(wait)
+	sleep(wait)
+}
+
+func (g *generatorSource[T]) idle() {
+	if g.idleBackoff == nil {
+		return
+	}
+	wait := g.idleBackoff.Next(g.consecutiveEmptyCount)
+
+	g.log(Verbose, "Generator idle %d time(s). Waiting %s before next poll.", g.consecutiveEmptyCount, wait)
+	sleep(wait)
+}
+
+func (g *generatorSource[T]) clearEmptyCount() {
+	if g.consecutiveEmptyCount > 0 {
+		g.log(Debug, "Clearing empty poll count")
+		g.consecutiveEmptyCount = 0
+		if g.idleBackoff != nil {
+			g.idleBackoff.Reset()
+		}
+	}
 }
 
 func (g *generatorSource[T]) clearErrorCount() {
 	if g.consecutiveErrorCount > 0 {
 		g.log(Debug, "Clearing error count")
 		g.consecutiveErrorCount = 0
+		if g.backoff != nil {
+			g.backoff.Reset()
+		}
 	}
 }
 
@@ -72,34 +119,120 @@ func (g *generatorSource[T]) clearErrorCount() {
 //
 // Note that this source can be cancelled via [CancellableSource.Cancel].
 func FromGenerator[T any](generator func() (*T, error)) CancellableSource[T] {
-	return FromGeneratorWithExponentialBackoff(generator, 0, 0)
+	return FromGeneratorWithBackoff(generator, nil)
 }
 
 // FromGeneratorWithDefaultBackoff is similar to FromGenerator, but waits at least 250ms and at most 10s
 func FromGeneratorWithDefaultBackoff[T any](generator func() (*T, error)) CancellableSource[T] {
-	return FromGeneratorWithExponentialBackoff(generator, 10000, 125)
+	return FromGeneratorWithBackoff(generator, ExponentialBackoff(125*time.Millisecond, 10*time.Second))
 }
 
 // FromGeneratorWithExponentialBackoff is similar to FromGenerator, but accepts parameters for implementing a exponential
 // backoff to prevent rapid polling.
 //   - maxBackoff maximum time to wait in milliseconds
 //   - backoffMultiplier the multiplier m in m*2^e where e is the error count
+//
+// See [FromGeneratorWithBackoff] and [ExponentialBackoff] for the equivalent using [time.Duration].
 func FromGeneratorWithExponentialBackoff[T any](
 	generator func() (*T, error),
 	maxBackoff float64,
 	backoffMultiplier float64,
+) CancellableSource[T] {
+	if maxBackoff == 0 {
+		return FromGeneratorWithBackoff(generator, nil)
+	}
+	return FromGeneratorWithBackoff(generator, ExponentialBackoff(
+		time.Duration(backoffMultiplier*float64(time.Millisecond)),
+		time.Duration(maxBackoff*float64(time.Millisecond)),
+	))
+}
+
+// FromGeneratorWithBackoff is similar to FromGenerator, but waits according to the provided [Backoff] after each
+// consecutive generator error. A nil backoff polls again immediately.
+func FromGeneratorWithBackoff[T any](generator func() (*T, error), backoff Backoff) CancellableSource[T] {
+	return FromGeneratorWithPolling(generator, backoff, nil)
+}
+
+// FromGeneratorWithPolling is similar to FromGeneratorWithBackoff, but also waits according to idleBackoff after each
+// consecutive empty (nil, nil) response, so an idle generator is not polled in a hot loop. The empty count is cleared
+// as soon as the generator returns an item, so the next poll after a hit happens immediately.
+//   - [ConstantBackoff] polls an idle generator at a fixed interval.
+//   - [LinearBackoff] and [ExponentialBackoff] slow down polling as consecutive empty responses accumulate.
+//
+// A nil backoff or idleBackoff polls again immediately.
+func FromGeneratorWithPolling[T any](
+	generator func() (*T, error),
+	backoff Backoff,
+	idleBackoff Backoff,
+) CancellableSource[T] {
+	return FromBatchGeneratorWithPolling(func() ([]T, error) {
+		item, err := generator()
+		if item == nil {
+			return nil, err
+		}
+		return []T{*item}, err
+	}, backoff, idleBackoff)
+}
+
+// FromGenerator3 is similar to FromGenerator, but the generator reports completion with a separate return value
+// instead of [GeneratorFinished]:
+//   - The generator should return (*T, false, nil) when an item is available.
+//   - If no item is available, the generator should return (nil, false, nil).
+//   - If the generator is complete it should return (*T, true, nil) or (nil, true, nil).
+//   - Errors may be returned via (nil, false, error).
+//
+// An error returned alongside done is logged and the source completes.
+func FromGenerator3[T any](generator func() (item *T, done bool, err error)) CancellableSource[T] {
+	return FromGenerator(func() (*T, error) {
+		item, done, err := generator()
+		if done && err != nil {
+			return item, errors.Join(err, &GeneratorFinished{})
+		}
+		if done {
+			return item, &GeneratorFinished{}
+		}
+		return item, err
+	})
+}
+
+// FromBatchGenerator is similar to FromGenerator, but the generator returns a slice of items per poll. Every item of
+// the slice is pumped, in order, before the generator is polled again. An empty slice is treated as an empty poll and
+// completion is signalled with [GeneratorFinished], as with FromGenerator.
+func FromBatchGenerator[T any](generator func() ([]T, error)) CancellableSource[T] {
+	return FromBatchGeneratorWithPolling(generator, nil, nil)
+}
+
+// FromBatchGeneratorWithPolling is similar to FromBatchGenerator, but waits according to backoff after generator
+// errors and idleBackoff after empty polls. See [FromGeneratorWithPolling].
+func FromBatchGeneratorWithPolling[T any](
+	generator func() ([]T, error),
+	backoff Backoff,
+	idleBackoff Backoff,
+) CancellableSource[T] {
+	return FromBatchGeneratorWithAck(generator, nil, backoff, idleBackoff)
+}
+
+// FromBatchGeneratorWithAck is similar to FromBatchGeneratorWithPolling, but calls ack with each item once every sink
+// has handled it without error. Items that any sink fails or panics on are not acknowledged, letting at least once
+// sources, such as message queues, redeliver them. An error from ack is logged. A nil ack acknowledges nothing.
+func FromBatchGeneratorWithAck[T any](
+	generator func() ([]T, error),
+	ack func(T) error,
+	backoff Backoff,
+	idleBackoff Backoff,
 ) CancellableSource[T] {
 	ret := generatorSource[T]{
-		generator:         generator,
-		maxBackoff:        maxBackoff,
-		backoffMultiplier: backoffMultiplier,
+		generator:   generator,
+		backoff:     backoff,
+		idleBackoff: idleBackoff,
+		ack:         ack,
 	}
 	ret.log(
 		Verbose,
-		"Creating Source with exp backoff: Generator (%p), maxBackoff (%.0fms), backoffMultiplier (%.0fms)",
+		"Creating Source with backoff: Generator (%p), backoff (%T), idleBackoff (%T)",
 		generator,
-		maxBackoff,
-		backoffMultiplier,
+		backoff,
+		idleBackoff,
 	)
 	ret.setStart(ret.start)
 	return &ret
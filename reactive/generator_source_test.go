@@ -3,6 +3,7 @@ package reactive
 import (
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -43,15 +44,15 @@ func TestFromGenerator_Cancelable(t *testing.T) {
 }
 
 func TestFromGenerator_Backoff(t *testing.T) {
-	callCount := 0
+	callCount := atomic.Int32{}
 	underTest := FromGeneratorWithExponentialBackoff(func() (*string, error) {
-		callCount++
+		callCount.Add(1)
 		return nil, errors.New("")
 	}, 100, 10)
 
 	underTest.Start()
 	time.Sleep(100 * time.Millisecond)
-	assert.Equal(t, 3, callCount)
+	assert.Equal(t, int32(3), callCount.Load())
 	err := underTest.Cancel()
 	assert.NoError(t, err)
 	underTest.AwaitCompletion()
@@ -81,15 +82,15 @@ func TestFromGenerator_ErrorsClear(t *testing.T) {
 }
 
 func TestFromGenerator_NoBackoff(t *testing.T) {
-	callCount := 0
+	callCount := atomic.Int32{}
 	underTest := FromGenerator(func() (*string, error) {
-		callCount++
+		callCount.Add(1)
 		return nil, errors.New("")
 	})
 
 	underTest.Start()
 	time.Sleep(100 * time.Millisecond)
-	assert.Greater(t, callCount, 300)
+	assert.Greater(t, callCount.Load(), int32(300))
 	err := underTest.Cancel()
 	assert.NoError(t, err)
 	underTest.AwaitCompletion()
@@ -114,3 +115,148 @@ func TestFromGenerator_ClosingPreventsFurtherObservations(t *testing.T) {
 	underTest.AwaitCompletion()
 	assert.Equal(t, 1, observeCount)
 }
+
+func TestFromGeneratorWithPolling_IdleBackoff(t *testing.T) {
+	callCount := atomic.Int32{}
+	underTest := FromGeneratorWithPolling(func() (*string, error) {
+		callCount.Add(1)
+		return nil, nil
+	}, nil, ConstantBackoff(10*time.Millisecond))
+
+	underTest.Start()
+	time.Sleep(55 * time.Millisecond)
+	assert.LessOrEqual(t, callCount.Load(), int32(6))
+	err := underTest.Cancel()
+	assert.NoError(t, err)
+	underTest.AwaitCompletion()
+}
+
+func TestFromGeneratorWithPolling_RepollsImmediatelyAfterHit(t *testing.T) {
+	callCount := 0
+	var waits []int
+	underTest := FromGeneratorWithPolling(func() (*int, error) {
+		callCount++
+		switch {
+		case callCount > 8:
+			return nil, &GeneratorFinished{}
+		case callCount%4 == 0:
+			return &callCount, nil
+		}
+		return nil, nil
+	}, nil, &recordingBackoff{Backoff: ExponentialBackoff(time.Millisecond, 0), attempts: &waits})
+
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3}, waits)
+}
+
+type recordingBackoff struct {
+	Backoff
+	attempts *[]int
+}
+
+func (r *recordingBackoff) Next(attempt int) time.Duration {
+	*r.attempts = append(*r.attempts, attempt)
+	return r.Backoff.Next(attempt)
+}
+
+func TestFromGenerator3_HappyPath(t *testing.T) {
+	messages := captureLogs(t)
+	responses := []string{"foobar", "test", "fizzbuzz"}
+	pos := 0
+	underTest := FromGenerator3(func() (*string, bool, error) {
+		if pos == 1 {
+			pos++
+			return nil, false, errors.New("test error")
+		}
+		ret := responses[pos%len(responses)]
+		pos++
+		return &ret, pos > len(responses), nil
+	})
+	results := make([]string, 0)
+	underTest.Observe(func(item string) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"foobar", "fizzbuzz", "foobar"}, results)
+	checkForLog(t, messages(), Debug, "indicates completion.")
+	for _, message := range messages() {
+		assert.NotContains(t, message, "completion with error")
+	}
+}
+
+func TestFromGenerator3_DoneWithError(t *testing.T) {
+	messages := captureLogs(t)
+	underTest := FromGenerator3(func() (*string, bool, error) {
+		return nil, true, errors.New("test error")
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	checkForLog(t, messages(), Info, "completion with error: [test error")
+}
+
+func TestFromBatchGenerator_PumpsEveryItem(t *testing.T) {
+	batches := [][]int{{1, 2, 3}, {}, {4}, {5, 6}}
+	pos := 0
+	underTest := FromBatchGenerator(func() ([]int, error) {
+		batch := batches[pos]
+		pos++
+		if pos == len(batches) {
+			return batch, &GeneratorFinished{}
+		}
+		return batch, nil
+	})
+	var results []int
+	underTest.Observe(func(item int) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, results)
+}
+
+func TestFromBatchGenerator_StopsPumpingWhenCancelled(t *testing.T) {
+	var underTest CancellableSource[int]
+	underTest = FromBatchGenerator(func() ([]int, error) {
+		return []int{1, 2, 3}, nil
+	})
+	var results []int
+	underTest.Observe(func(item int) error {
+		results = append(results, item)
+		return underTest.Cancel()
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []int{1}, results)
+}
+
+func TestFromBatchGeneratorWithAck_OnlyAcknowledgesDeliveredItems(t *testing.T) {
+	var acknowledged []int
+	underTest := FromBatchGeneratorWithAck(func() ([]int, error) {
+		return []int{1, 2, 3, 4}, &GeneratorFinished{}
+	}, func(item int) error {
+		acknowledged = append(acknowledged, item)
+		if item == 4 {
+			return errors.New("test error")
+		}
+		return nil
+	}, nil, nil)
+	underTest.Observe(func(item int) error {
+		if item == 2 {
+			return errors.New("test error")
+		}
+		return nil
+	})
+	underTest.Observe(func(item int) error {
+		if item == 3 {
+			panic("test panic!")
+		}
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []int{1, 4}, acknowledged)
+}
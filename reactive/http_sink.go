@@ -0,0 +1,140 @@
+package reactive
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HTTPStatusError is returned by [HTTPSink] when the endpoint responds with a status outside of 2xx.
+type HTTPStatusError struct {
+	StatusCode int
+	// Wait is the delay requested by the response's Retry-After header, if any.
+	Wait time.Duration
+}
+
+// Error implements the error interface
+func (h *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status: %d %s", h.StatusCode, http.StatusText(h.StatusCode))
+}
+
+// RetryAfter returns the delay requested by the endpoint. [RetryPolicy] waits at least this long before retrying, up
+// to its MaxBackoff.
+func (h *HTTPStatusError) RetryAfter() time.Duration {
+	return h.Wait
+}
+
+// HTTPSinkOptions configures an [HTTPSink]. Zero values are replaced with the documented defaults.
+type HTTPSinkOptions[T any] struct {
+	// Retry is the policy applied to failed requests. Its MaxAttempts defaults to 1, meaning no retries. When
+	// Retryable is nil, network errors, 5xx and 429 responses are retried and other failures are not.
+	Retry RetryPolicy
+	// Timeout limits each attempt, including reading the response. Zero means no limit beyond the client's own.
+	Timeout time.Duration
+	// ContentType is sent with each request. Defaults to "application/json".
+	ContentType string
+	// IdempotencyKey returns the value of the Idempotency-Key header for an item. The same key is sent on every
+	// attempt for the item. Defaults to a random key per item.
+	IdempotencyKey func(T) string
+}
+
+// HTTPSink returns a [Sink] posting each item, encoded with the provided function, to the url. A nil client means
+// [http.DefaultClient]. The sink returns an error once the request fails for good, reporting it through the
+// observed [Source]'s error path; see [WithDeadLetter]. Failed responses are returned as [HTTPStatusError].
+//
+// To post batches, observe a [Batch] of the items with an HTTPSink of slices:
+//
+//	source := Batch(events, 100, time.Second)
+//	source.Observe(HTTPSink(nil, url, func(batch []Event) ([]byte, error) {
+//	  return json.Marshal(batch)
+//	}, HTTPSinkOptions[[]Event]{}))
+func HTTPSink[T any](
+	client *http.Client,
+	url string,
+	encode func(T) ([]byte, error),
+	options HTTPSinkOptions[T],
+) Sink[T] {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if options.ContentType == "" {
+		options.ContentType = "application/json"
+	}
+	if options.IdempotencyKey == nil {
+		options.IdempotencyKey = randomKey[T]
+	}
+	if options.Retry.Retryable == nil {
+		options.Retry.Retryable = retryableHTTPError
+	}
+	id := fmt.Sprintf("http(%s)", url)
+	return func(item T) error {
+		body, err := encode(item)
+		if err != nil {
+			return err
+		}
+		key := options.IdempotencyKey(item)
+		return options.Retry.do(id, func() error {
+			return postOnce(client, url, body, key, options)
+		})
+	}
+}
+
+func postOnce[T any](client *http.Client, url string, body []byte, key string, options HTTPSinkOptions[T]) error {
+	ctx := context.Background()
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", options.ContentType)
+	request.Header.Set("Idempotency-Key", key)
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, err = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return &HTTPStatusError{
+			StatusCode: response.StatusCode,
+			Wait:       retryAfter(response.Header.Get("Retry-After")),
+		}
+	}
+	return err
+}
+
+// retryAfter parses a Retry-After header, either a number of seconds or an HTTP date.
+func retryAfter(header string) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(at.Sub(clock.Now()), 0)
+	}
+	return 0
+}
+
+func retryableHTTPError(err error) bool {
+	var statusError *HTTPStatusError
+	if !errors.As(err, &statusError) {
+		return true
+	}
+	return statusError.StatusCode == http.StatusTooManyRequests || statusError.StatusCode >= 500
+}
+
+func randomKey[T any](T) string {
+	key := make([]byte, 16)
+	_, _ = rand.Read(key)
+	return hex.EncodeToString(key)
+}
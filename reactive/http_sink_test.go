@@ -0,0 +1,137 @@
+package reactive
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordedRequest struct {
+	body        string
+	contentType string
+	key         string
+}
+
+// endpoint returns a server responding with the provided statuses in turn, then 200.
+func endpoint(t *testing.T, statuses ...int) (*httptest.Server, func() []recordedRequest) {
+	lock := sync.Mutex{}
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, recordedRequest{
+			body:        string(body),
+			contentType: request.Header.Get("Content-Type"),
+			key:         request.Header.Get("Idempotency-Key"),
+		})
+		if len(requests) <= len(statuses) {
+			if statuses[len(requests)-1] == http.StatusTooManyRequests {
+				writer.Header().Set("Retry-After", "2")
+			}
+			writer.WriteHeader(statuses[len(requests)-1])
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []recordedRequest {
+		lock.Lock()
+		defer lock.Unlock()
+		return requests
+	}
+}
+
+func marshal[T any](item T) ([]byte, error) {
+	return json.Marshal(item)
+}
+
+func TestHTTPSink_HappyPath(t *testing.T) {
+	server, requests := endpoint(t)
+	underTest := HTTPSink(server.Client(), server.URL, marshal[decoded], HTTPSinkOptions[decoded]{})
+	assert.NoError(t, underTest(decoded{Name: "foobar", Count: 1}))
+	require.Len(t, requests(), 1)
+	assert.JSONEq(t, `{"name":"foobar","count":1,"Ratio":0,"Enabled":false,"Size":0,"Skipped":""}`, requests()[0].body)
+	assert.Equal(t, "application/json", requests()[0].contentType)
+	assert.Len(t, requests()[0].key, 32)
+}
+
+func TestHTTPSink_RetriesServerErrorsWithSameKey(t *testing.T) {
+	server, requests := endpoint(t, http.StatusServiceUnavailable, http.StatusBadGateway)
+	underTest := HTTPSink(server.Client(), server.URL, marshal[int], HTTPSinkOptions[int]{
+		Retry: RetryPolicy{MaxAttempts: 3, BackoffMultiplier: time.Millisecond},
+	})
+	assert.NoError(t, underTest(42))
+	require.Len(t, requests(), 3)
+	assert.Equal(t, requests()[0].key, requests()[2].key)
+}
+
+func TestHTTPSink_HonorsRetryAfter(t *testing.T) {
+	fake := useFakeClock(t)
+	server, requests := endpoint(t, http.StatusTooManyRequests)
+	underTest := HTTPSink(server.Client(), server.URL, marshal[int], HTTPSinkOptions[int]{
+		Retry:          RetryPolicy{MaxAttempts: 2, BackoffMultiplier: time.Millisecond},
+		IdempotencyKey: func(item int) string { return "key-42" },
+	})
+	result := make(chan error)
+	go func() {
+		result <- underTest(42)
+	}()
+	fake.AwaitWaiters(1)
+	fake.Advance(time.Second)
+	assert.Len(t, requests(), 1)
+	fake.Advance(time.Second)
+	assert.NoError(t, <-result)
+	require.Len(t, requests(), 2)
+	assert.Equal(t, "key-42", requests()[1].key)
+}
+
+func TestHTTPSink_DoesNotRetryClientErrors(t *testing.T) {
+	server, requests := endpoint(t, http.StatusBadRequest)
+	underTest := HTTPSink(server.Client(), server.URL, marshal[int], HTTPSinkOptions[int]{
+		Retry: RetryPolicy{MaxAttempts: 3, BackoffMultiplier: time.Millisecond},
+	})
+	err := underTest(42)
+	var statusError *HTTPStatusError
+	require.ErrorAs(t, err, &statusError)
+	assert.Equal(t, http.StatusBadRequest, statusError.StatusCode)
+	assert.Len(t, requests(), 1)
+}
+
+func TestHTTPSink_TimesOutRequests(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	underTest := HTTPSink(server.Client(), server.URL, marshal[int], HTTPSinkOptions[int]{
+		Timeout: 10 * time.Millisecond,
+	})
+	assert.ErrorIs(t, underTest(42), context.DeadlineExceeded)
+}
+
+func TestHTTPSink_PostsBatches(t *testing.T) {
+	server, requests := endpoint(t)
+	source := Just(1, 2, 3, 4, 5)
+	batches := Batch(source, 2, 0)
+	batches.Observe(HTTPSink(server.Client(), server.URL, marshal[[]int], HTTPSinkOptions[[]int]{}))
+	source.Start()
+	source.AwaitCompletion()
+	batches.AwaitCompletion()
+	require.Len(t, requests(), 3)
+	assert.Equal(t, "[1,2]", requests()[0].body)
+	assert.Equal(t, "[5]", requests()[2].body)
+}
+
+func TestRetryAfter_ParsesDates(t *testing.T) {
+	fake := useFakeClock(t)
+	assert.Equal(t, 30*time.Second, retryAfter(fake.Now().Add(30*time.Second).Format(http.TimeFormat)))
+	assert.Equal(t, 5*time.Second, retryAfter("5"))
+	assert.Zero(t, retryAfter("soon"))
+}
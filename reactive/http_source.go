@@ -0,0 +1,159 @@
+package reactive
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// DefaultHTTPBuffer is the number of accepted requests [HTTPSource] holds before responding 429 Too Many Requests.
+const DefaultHTTPBuffer = 100
+
+// DefaultHTTPMaxBodyBytes is the largest request body [HTTPSource] reads before responding 413 Request Entity Too
+// Large.
+const DefaultHTTPMaxBodyBytes = 1 << 20
+
+// HTTPSourceOptions configures an [HTTPSource]. Zero values are replaced with the documented defaults.
+type HTTPSourceOptions struct {
+	// Buffer is the number of accepted items held until sinks take them. Defaults to [DefaultHTTPBuffer].
+	Buffer int
+	// MaxBodyBytes limits the size of each request body. Defaults to [DefaultHTTPMaxBodyBytes].
+	MaxBodyBytes int64
+}
+
+type httpSource[T any] struct {
+	*subjectSource[T]
+	decode       func(*http.Request) (T, error)
+	items        chan T
+	maxBodyBytes int64
+	lock         sync.RWMutex
+	closed       bool
+}
+
+// HTTPSource returns an [http.Handler] turning each POST it receives into an item, and the [CancellableSource] of
+// those items. Requests are decoded with the provided decode function, for example [DecodeJSON]. The handler
+// responds:
+//   - 202 Accepted once the item is buffered.
+//   - 400 Bad Request when decode fails.
+//   - 405 Method Not Allowed for anything but POST.
+//   - 413 Request Entity Too Large when the body exceeds [DefaultHTTPMaxBodyBytes].
+//   - 429 Too Many Requests when the buffer is full because sinks are not keeping up.
+//   - 503 Service Unavailable once the source is cancelled.
+//
+// Cancelling the source closes it gracefully: new requests are refused, and already accepted items are still
+// delivered before the source completes. The buffer holds [DefaultHTTPBuffer] items; see [HTTPSourceWithOptions].
+func HTTPSource[T any](decode func(*http.Request) (T, error)) (http.Handler, CancellableSource[T]) {
+	return HTTPSourceWithOptions(decode, HTTPSourceOptions{})
+}
+
+// HTTPSourceWithBuffer is similar to [HTTPSource], but buffers up to size accepted items.
+func HTTPSourceWithBuffer[T any](decode func(*http.Request) (T, error), size int) (http.Handler, CancellableSource[T]) {
+	return HTTPSourceWithOptions(decode, HTTPSourceOptions{Buffer: size})
+}
+
+// HTTPSourceWithOptions is similar to [HTTPSource], but configured by the provided options.
+func HTTPSourceWithOptions[T any](
+	decode func(*http.Request) (T, error),
+	options HTTPSourceOptions,
+) (http.Handler, CancellableSource[T]) {
+	if options.Buffer == 0 {
+		options.Buffer = DefaultHTTPBuffer
+	}
+	if options.MaxBodyBytes == 0 {
+		options.MaxBodyBytes = DefaultHTTPMaxBodyBytes
+	}
+	ret := &httpSource[T]{
+		subjectSource: newSubjectSource[T](),
+		decode:        decode,
+		items:         make(chan T, options.Buffer),
+		maxBodyBytes:  options.MaxBodyBytes,
+	}
+	ret.log(Verbose, "Creating HTTP Source: buffer (%d), max body (%d bytes)", options.Buffer, options.MaxBodyBytes)
+	ret.setStart(ret.start)
+	return ret, ret
+}
+
+// DecodeJSON decodes the body of the request as JSON. It may be passed to [HTTPSource].
+func DecodeJSON[T any](request *http.Request) (T, error) {
+	var ret T
+	err := json.NewDecoder(request.Body).Decode(&ret)
+	return ret, err
+}
+
+func (h *httpSource[T]) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		http.Error(writer, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if h.isClosed() {
+		http.Error(writer, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	request.Body = http.MaxBytesReader(writer, request.Body, h.maxBodyBytes)
+	item, err := h.decode(request)
+	if err != nil {
+		h.log(Debug, "Failed to decode request from %s: [%v]", request.RemoteAddr, err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(writer, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	// Cancel may have been called while decoding.
+	if h.closed {
+		http.Error(writer, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	select {
+	case h.items <- item:
+		writer.WriteHeader(http.StatusAccepted)
+	default:
+		h.log(Verbose, "Buffer full, refusing item (%.10v).", item)
+		writer.Header().Set("Retry-After", "1")
+		http.Error(writer, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+	}
+}
+
+func (h *httpSource[T]) isClosed() bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return h.closed
+}
+
+// Cancel refuses further requests, then completes the source once the accepted items have been delivered.
+func (h *httpSource[T]) Cancel() error {
+	h.lock.Lock()
+	h.closed = true
+	h.lock.Unlock()
+	return h.subjectSource.Cancel()
+}
+
+func (h *httpSource[T]) start() {
+	for {
+		select {
+		case item := <-h.items:
+			h.pump(item)
+		case <-h.done:
+			h.drain()
+			return
+		}
+	}
+}
+
+// drain delivers the buffered items. Cancel has already marked the source closed, so no more items arrive.
+func (h *httpSource[T]) drain() {
+	for {
+		select {
+		case item := <-h.items:
+			h.pump(item)
+		default:
+			return
+		}
+	}
+}
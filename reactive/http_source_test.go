@@ -0,0 +1,97 @@
+package reactive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func post(t *testing.T, server *httptest.Server, body string) int {
+	response, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	return response.StatusCode
+}
+
+func TestHTTPSource_HappyPath(t *testing.T) {
+	handler, underTest := HTTPSource(DecodeJSON[decoded])
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	var results []decoded
+	underTest.Observe(func(item decoded) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	assert.Equal(t, http.StatusAccepted, post(t, server, `{"name":"foobar","count":1}`))
+	assert.Equal(t, http.StatusAccepted, post(t, server, `{"name":"test","count":2}`))
+	require.NoError(t, underTest.Cancel())
+	underTest.AwaitCompletion()
+	assert.Equal(t, []decoded{{Name: "foobar", Count: 1}, {Name: "test", Count: 2}}, results)
+}
+
+func TestHTTPSource_RejectsMalformedRequests(t *testing.T) {
+	handler, _ := HTTPSource(DecodeJSON[decoded])
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	assert.Equal(t, http.StatusBadRequest, post(t, server, `{not json}`))
+	response, err := http.Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, response.StatusCode)
+	assert.Equal(t, http.MethodPost, response.Header.Get("Allow"))
+}
+
+func TestHTTPSource_RefusesWhenBufferFull(t *testing.T) {
+	handler, _ := HTTPSourceWithBuffer(DecodeJSON[int], 1)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	assert.Equal(t, http.StatusAccepted, post(t, server, "1"))
+	response, err := http.Post(server.URL, "application/json", strings.NewReader("2"))
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, "1", response.Header.Get("Retry-After"))
+}
+
+func TestHTTPSource_DeliversAcceptedItemsAfterCancel(t *testing.T) {
+	handler, underTest := HTTPSourceWithBuffer(DecodeJSON[int], 2)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	assert.Equal(t, http.StatusAccepted, post(t, server, "1"))
+	assert.Equal(t, http.StatusAccepted, post(t, server, "2"))
+	require.NoError(t, underTest.Cancel())
+	assert.Equal(t, http.StatusServiceUnavailable, post(t, server, "3"))
+	var results []int
+	underTest.Observe(func(item int) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []int{1, 2}, results)
+}
+
+func TestHTTPSource_RefusesLargeBodies(t *testing.T) {
+	handler, _ := HTTPSourceWithOptions(DecodeJSON[string], HTTPSourceOptions{MaxBodyBytes: 8})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	assert.Equal(t, http.StatusAccepted, post(t, server, `"small"`))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(t, server, `"much too large"`))
+}
+
+func TestHTTPSource_DoesNotDecodeOnceCancelled(t *testing.T) {
+	decodes := 0
+	handler, underTest := HTTPSource(func(request *http.Request) (int, error) {
+		decodes++
+		return DecodeJSON[int](request)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	require.NoError(t, underTest.Cancel())
+	assert.Equal(t, http.StatusServiceUnavailable, post(t, server, "1"))
+	assert.Equal(t, 0, decodes)
+}
@@ -0,0 +1,61 @@
+package reactive
+
+import (
+	"time"
+)
+
+// Interval returns a [CancellableSource] emitting 0, 1, 2, ... with the provided period between items, starting one
+// period after Start. The source runs until cancelled.
+func Interval(period time.Duration) CancellableSource[int64] {
+	ret := newSubjectSource[int64]()
+	ret.log(Verbose, "Creating interval Source: period (%s)", period)
+	ret.setStart(func() {
+		for tick := int64(0); ; tick++ {
+			select {
+			case <-ret.done:
+				return
+			case <-clock.After(period):
+				ret.pump(tick)
+			}
+		}
+	})
+	return ret
+}
+
+// Timer returns a [CancellableSource] emitting the current time once, delay after Start, and then completing.
+func Timer(delay time.Duration) CancellableSource[time.Time] {
+	ret := newSubjectSource[time.Time]()
+	ret.log(Verbose, "Creating timer Source: delay (%s)", delay)
+	ret.setStart(func() {
+		select {
+		case <-ret.done:
+		case now := <-clock.After(delay):
+			ret.pump(now)
+		}
+	})
+	return ret
+}
+
+// Range returns a [CancellableSource] emitting count consecutive integers beginning with start.
+func Range(start int, count int) CancellableSource[int] {
+	ret := newSubjectSource[int]()
+	ret.log(Verbose, "Creating range Source: start (%d), count (%d)", start, count)
+	ret.setStart(func() {
+		for item := start; item < start+count && !ret.cancelled(); item++ {
+			ret.pump(item)
+		}
+	})
+	return ret
+}
+
+// Repeat returns a [CancellableSource] emitting the provided item n times. A negative n repeats until cancelled.
+func Repeat[T any](item T, n int) CancellableSource[T] {
+	ret := newSubjectSource[T]()
+	ret.log(Verbose, "Creating repeating Source: item (%.10v), n (%d)", item, n)
+	ret.setStart(func() {
+		for count := 0; (n < 0 || count < n) && !ret.cancelled(); count++ {
+			ret.pump(item)
+		}
+	})
+	return ret
+}
@@ -0,0 +1,92 @@
+package reactive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInterval_TicksUntilCancelled(t *testing.T) {
+	fake := useFakeClock(t)
+	underTest := Interval(time.Second)
+	results := make(chan int64, 10)
+	underTest.Observe(func(tick int64) error {
+		results <- tick
+		return nil
+	})
+	underTest.Start()
+	for expected := int64(0); expected < 3; expected++ {
+		fake.AwaitWaiters(1)
+		fake.Advance(time.Second)
+		assert.Equal(t, expected, <-results)
+	}
+	assert.NoError(t, underTest.Cancel())
+	underTest.AwaitCompletion()
+}
+
+func TestTimer_EmitsOnce(t *testing.T) {
+	fake := useFakeClock(t)
+	underTest := Timer(time.Minute)
+	var results []time.Time
+	underTest.Observe(func(now time.Time) error {
+		results = append(results, now)
+		return nil
+	})
+	start := fake.Now()
+	underTest.Start()
+	fake.AwaitWaiters(1)
+	fake.Advance(time.Hour)
+	underTest.AwaitCompletion()
+	assert.Equal(t, []time.Time{start.Add(time.Minute)}, results)
+}
+
+func TestTimer_Cancelable(t *testing.T) {
+	useFakeClock(t)
+	underTest := Timer(time.Minute)
+	underTest.Observe(func(time.Time) error {
+		t.Fail()
+		return nil
+	})
+	underTest.Start()
+	assert.NoError(t, underTest.Cancel())
+	underTest.AwaitCompletion()
+}
+
+func TestRange_HappyPath(t *testing.T) {
+	underTest := Range(5, 3)
+	var results []int
+	underTest.Observe(func(item int) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []int{5, 6, 7}, results)
+}
+
+func TestRepeat_HappyPath(t *testing.T) {
+	underTest := Repeat("test", 3)
+	var results []string
+	underTest.Observe(func(item string) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"test", "test", "test"}, results)
+}
+
+func TestRepeat_ForeverUntilCancelled(t *testing.T) {
+	underTest := Repeat("test", -1)
+	count := 0
+	underTest.Observe(func(item string) error {
+		count++
+		if count == 10 {
+			return underTest.Cancel()
+		}
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, 10, count)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"sync/atomic"
 )
 
 // Level represents a log level ranging from Verbose to Error.
@@ -46,7 +47,11 @@ func (l Level) String() string {
 	return strconv.Itoa(int(l))
 }
 
-var logger = func(level Level, source interface{}, formatString string, args ...interface{}) {
+type loggerFunc = func(Level, interface{}, string, ...interface{})
+
+var currentLogger atomic.Pointer[loggerFunc]
+
+func defaultLogger(level Level, source interface{}, formatString string, args ...interface{}) {
 	if level < Warning {
 		return
 	}
@@ -54,6 +59,14 @@ var logger = func(level Level, source interface{}, formatString string, args ...
 	log.Printf("%s [%s]: %s\n", level, source, message)
 }
 
+func init() {
+	SetLogger(defaultLogger)
+}
+
+func logger(level Level, source interface{}, formatString string, args ...interface{}) {
+	(*currentLogger.Load())(level, source, formatString, args...)
+}
+
 // SetLogger sets the logger for all logging in the reactive package. Default logger implementation:
 //
 //	func(level Level, source interface{}, formatString string, args ...interface{}) {
@@ -65,7 +78,7 @@ var logger = func(level Level, source interface{}, formatString string, args ...
 //	}
 //
 // Note that the formatting string should not be evaluated until after filtering by log [Level].
-// Formatting Verbose and Debug logs can create superfluous cpu load.
+// Formatting Verbose and Debug logs can create superfluous cpu load. SetLogger may be called while Sources are running.
 func SetLogger(newLogger func(Level, interface{}, string, ...interface{})) {
-	logger = newLogger
+	currentLogger.Store(&newLogger)
 }
@@ -5,15 +5,33 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"strings"
+	"sync"
 	"testing"
 )
 
-func TestLogging_EmitsMessages(t *testing.T) {
+// captureLogs replaces the logger with one recording every message until the test ends, returning a function that
+// copies the messages recorded so far.
+func captureLogs(t *testing.T) func() []string {
+	t.Cleanup(func() {
+		SetLogger(defaultLogger)
+	})
+	lock := sync.Mutex{}
 	var messages []string
 	SetLogger(func(level Level, source interface{}, messageFormat string, args ...interface{}) {
 		message := fmt.Sprintf(messageFormat, args...)
+		lock.Lock()
+		defer lock.Unlock()
 		messages = append(messages, fmt.Sprintf("%s [%s]: %s", level, source, message))
 	})
+	return func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string(nil), messages...)
+	}
+}
+
+func TestLogging_EmitsMessages(t *testing.T) {
+	messages := captureLogs(t)
 	underTest := FromSlice([]string{"test"})
 	underTest.Observe(func(string) error {
 		return errors.New("expected error")
@@ -26,11 +44,11 @@ func TestLogging_EmitsMessages(t *testing.T) {
 	})
 	underTest.Start()
 	underTest.AwaitCompletion()
-	checkForLog(t, messages, Warning, "expected error")
-	checkForLog(t, messages, Error, "expected panic")
-	checkForLog(t, messages, Info, "Source is closed")
-	checkForLog(t, messages, Debug, "Registering sink")
-	checkForLog(t, messages, Verbose, "Beginning to send item (test)")
+	checkForLog(t, messages(), Warning, "expected error")
+	checkForLog(t, messages(), Error, "expected panic")
+	checkForLog(t, messages(), Info, "Source is closed")
+	checkForLog(t, messages(), Debug, "Registering sink")
+	checkForLog(t, messages(), Verbose, "Beginning to send item (test)")
 }
 func TestLevel_String_shouldHandleStrangeValues(t *testing.T) {
 	assert.NotPanics(t, func() {
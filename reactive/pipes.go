@@ -1,5 +1,10 @@
 package reactive
 
+import (
+	"fmt"
+	"sync"
+)
+
 // Map observes one Source, transform the items observed with the provided mapper function,
 // and returns a Source of the transformed items. If the mapper returns an error the item dropped, it is not retried.
 //
@@ -13,7 +18,6 @@ func Map[T any, V any](source Source[T], mapper func(T) (V, error)) Source[V] {
 		ret.AwaitCompletion()
 	})
 	source.Observe(func(item T) error {
-		defer ret.logPanic(mapper)
 		transformed, err := mapper(item)
 		if err != nil {
 			ret.log(Warning, "Error mapping item (%.10s): [%v]", item, err)
@@ -48,3 +52,127 @@ func Buffer[T any](source Source[T], size int) Source[T] {
 	ret.Start()
 	return ret
 }
+
+// MapConcurrent is similar to [Map], but runs up to parallelism mappers at once. Each observed item is handed to its
+// own go routine, so a slow mapper does not hold up the observed Source until parallelism mappers are in flight.
+//
+// When ordered is true the transformed items are emitted in the order they were observed. Results that complete early
+// wait in a reorder buffer that holds at most parallelism items. When ordered is false transformed items are emitted
+// as soon as they are available. MapConcurrent panics if parallelism is less than 1.
+//
+// The returned Source is already started.
+func MapConcurrent[T any, V any](source Source[T], mapper func(T) (V, error), parallelism int, ordered bool) Source[V] {
+	if parallelism < 1 {
+		panic(fmt.Sprintf("reactive: MapConcurrent parallelism must be at least 1, found %d", parallelism))
+	}
+	c := make(chan V)
+	ret := fromChan(c)
+	deadLetter := func(T, error) {}
+	if routable, ok := source.(interface {
+		deadLetter(item T, sink interface{}, err error)
+	}); ok {
+		deadLetter = func(item T, err error) {
+			routable.deadLetter(item, mapper, err)
+		}
+	}
+	mapItem := func(item T) (transformed V, ok bool) {
+		defer func() {
+			if err := recover(); err != nil {
+				ret.log(Error, "Panic from (%p)! [%v]", mapper, err)
+				deadLetter(item, fmt.Errorf("panic: %v", err))
+				ok = false
+			}
+		}()
+		transformed, err := mapper(item)
+		if err != nil {
+			ret.log(Warning, "Error mapping item (%.10v): [%v]", item, err)
+			deadLetter(item, err)
+			return transformed, false
+		}
+		ret.log(Verbose, "Mapped item (%.10v) to (%.10v)", item, transformed)
+		return transformed, true
+	}
+	if ordered {
+		observeOrdered(source, ret, c, mapItem, parallelism)
+	} else {
+		observeUnordered(source, ret, c, mapItem, parallelism)
+	}
+	ret.log(Debug, "Created concurrent mapped source with mapper (%p), parallelism (%d), ordered (%t).",
+		mapper, parallelism, ordered)
+	ret.Start()
+	return ret
+}
+
+type pendingResult[V any] struct {
+	done  chan struct{}
+	value V
+	ok    bool
+}
+
+func observeOrdered[T any, V any](
+	source Source[T],
+	ret *chanSource[V],
+	c chan V,
+	mapItem func(T) (V, bool),
+	parallelism int,
+) {
+	slots := make(chan struct{}, parallelism)
+	pending := make(chan *pendingResult[V], parallelism)
+	emitted := make(chan struct{})
+	go func() {
+		defer close(emitted)
+		for result := range pending {
+			<-result.done
+			if result.ok {
+				c <- result.value
+			}
+			<-slots
+		}
+	}()
+	source.UponClose(func() {
+		close(pending)
+		<-emitted
+		ret.log(Debug, "Closing concurrent mapping chan (%p)", c)
+		close(c)
+		ret.AwaitCompletion()
+	})
+	source.Observe(func(item T) error {
+		slots <- struct{}{}
+		result := &pendingResult[V]{done: make(chan struct{})}
+		pending <- result
+		go func() {
+			defer close(result.done)
+			result.value, result.ok = mapItem(item)
+		}()
+		return nil
+	})
+}
+
+func observeUnordered[T any, V any](
+	source Source[T],
+	ret *chanSource[V],
+	c chan V,
+	mapItem func(T) (V, bool),
+	parallelism int,
+) {
+	slots := make(chan struct{}, parallelism)
+	wg := sync.WaitGroup{}
+	source.UponClose(func() {
+		wg.Wait()
+		ret.log(Debug, "Closing concurrent mapping chan (%p)", c)
+		close(c)
+		ret.AwaitCompletion()
+	})
+	source.Observe(func(item T) error {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if transformed, ok := mapItem(item); ok {
+				c <- transformed
+			}
+		}()
+		return nil
+	})
+}
@@ -2,10 +2,10 @@ package reactive
 
 import (
 	"errors"
-	"fmt"
 	"github.com/stretchr/testify/assert"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -47,11 +47,7 @@ func TestMap_CallsUponClose(t *testing.T) {
 }
 
 func TestMap_HandlesErrors(t *testing.T) {
-	var messages []string
-	SetLogger(func(level Level, source interface{}, messageFormat string, args ...interface{}) {
-		message := fmt.Sprintf(messageFormat, args...)
-		messages = append(messages, fmt.Sprintf("%s [%s]: %s", level, source, message))
-	})
+	messages := captureLogs(t)
 	c := make(chan string)
 	source := FromChan(c)
 	Map(source, func(item string) (string, error) {
@@ -61,7 +57,7 @@ func TestMap_HandlesErrors(t *testing.T) {
 	c <- "test"
 	close(c)
 	source.AwaitCompletion()
-	for _, message := range messages {
+	for _, message := range messages() {
 		if strings.Contains(message, "[test error]") && strings.Contains(message, "Warning") {
 			return
 		}
@@ -102,3 +98,89 @@ func TestBuffer_CanAddWithoutObserving(t *testing.T) {
 	// one in the source waiting to get into the chan, one in the sink waiting to sink, 10 in the buffer
 	assert.Equal(t, 12, generatorCallCount)
 }
+
+func TestMapConcurrent_Ordered(t *testing.T) {
+	source := Just(50, 10, 30, 0, 20)
+	mapped := MapConcurrent(source, func(item int) (string, error) {
+		time.Sleep(time.Duration(item) * time.Millisecond)
+		return strconv.Itoa(item), nil
+	}, 5, true)
+	var result []string
+	mapped.Observe(func(item string) error {
+		result = append(result, item)
+		return nil
+	})
+	start := time.Now()
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, []string{"50", "10", "30", "0", "20"}, result)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestMapConcurrent_Unordered(t *testing.T) {
+	source := Just(50, 0)
+	mapped := MapConcurrent(source, func(item int) (string, error) {
+		time.Sleep(time.Duration(item) * time.Millisecond)
+		return strconv.Itoa(item), nil
+	}, 2, false)
+	var result []string
+	mapped.Observe(func(item string) error {
+		result = append(result, item)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, []string{"0", "50"}, result)
+}
+
+func TestMapConcurrent_BoundsParallelism(t *testing.T) {
+	lock := sync.Mutex{}
+	running := 0
+	maxRunning := 0
+	source := Just(1, 2, 3, 4, 5, 6, 7, 8)
+	for _, ordered := range []bool{true, false} {
+		MapConcurrent(source, func(item int) (int, error) {
+			lock.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			lock.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			lock.Lock()
+			running--
+			lock.Unlock()
+			return item, nil
+		}, 2, ordered)
+	}
+	source.Start()
+	source.AwaitCompletion()
+	assert.LessOrEqual(t, maxRunning, 4)
+}
+
+func TestMapConcurrent_DropsErrorsAndPanics(t *testing.T) {
+	source := Just(1, 2, 3)
+	mapped := MapConcurrent(source, func(item int) (int, error) {
+		if item == 1 {
+			return 0, errors.New("test error")
+		}
+		if item == 2 {
+			panic("test panic")
+		}
+		return item, nil
+	}, 2, true)
+	var result []int
+	mapped.Observe(func(item int) error {
+		result = append(result, item)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, []int{3}, result)
+}
+
+func TestMapConcurrent_RejectsInvalidParallelism(t *testing.T) {
+	mapper := func(item int) (int, error) {
+		return item, nil
+	}
+	assert.Panics(t, func() { MapConcurrent(Just(1), mapper, 0, true) })
+	assert.Panics(t, func() { MapConcurrent(Just(1), mapper, -1, false) })
+}
@@ -0,0 +1,130 @@
+package reactive
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// tokenBucket holds up to burst tokens, refilled at rate tokens per second.
+type tokenBucket struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket panics, naming the operator, if rate or burst is not positive.
+func newTokenBucket(operator string, rate float64, burst int) *tokenBucket {
+	if !(rate > 0) {
+		panic(fmt.Sprintf("reactive: %s rate must be positive, found %v", operator, rate))
+	}
+	if burst < 1 {
+		panic(fmt.Sprintf("reactive: %s burst must be positive, found %d", operator, burst))
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clock.Now(),
+	}
+}
+
+func (b *tokenBucket) refill() {
+	now := clock.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// reserve takes a token, returning how long to wait until the token is available.
+func (b *tokenBucket) reserve() time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill()
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// take takes a token if one is available.
+func (b *tokenBucket) take() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// RateLimit observes one [Source] and returns a [Source] emitting the observed items at no more than rate items per
+// second, with bursts of up to burst items. Items are delayed, not dropped, so a slow rate applies backpressure to the
+// observed Source. See [RateLimitDropping] to drop items instead. RateLimit panics if rate or burst is not positive.
+//
+// The returned Source is already started.
+func RateLimit[T any](source Source[T], rate float64, burst int) Source[T] {
+	c := make(chan T)
+	ret := fromChan(c)
+	bucket := newTokenBucket("RateLimit", rate, burst)
+	source.UponClose(func() {
+		ret.log(Debug, "Closing rate limited chan (%p).", c)
+		close(c)
+		ret.AwaitCompletion()
+	})
+	source.Observe(func(item T) error {
+		if wait := bucket.reserve(); wait > 0 {
+			ret.log(Debug, "Throttling item (%.10v) for %s.", item, wait)
+			sleep(wait)
+		}
+		c <- item
+		return nil
+	})
+	ret.log(Debug, "Created rate limited source. rate (%.2f/s), burst (%d).", rate, burst)
+	ret.Start()
+	return ret
+}
+
+// RateLimitDropping is similar to [RateLimit], but drops items observed while the rate is exceeded instead of
+// delaying them. RateLimitDropping panics if rate or burst is not positive.
+//
+// The returned Source is already started.
+func RateLimitDropping[T any](source Source[T], rate float64, burst int) Source[T] {
+	c := make(chan T)
+	ret := fromChan(c)
+	bucket := newTokenBucket("RateLimitDropping", rate, burst)
+	source.UponClose(func() {
+		ret.log(Debug, "Closing rate limited chan (%p).", c)
+		close(c)
+		ret.AwaitCompletion()
+	})
+	source.Observe(func(item T) error {
+		if !bucket.take() {
+			ret.log(Debug, "Rate exceeded. Dropping item (%.10v).", item)
+			return nil
+		}
+		c <- item
+		return nil
+	})
+	ret.log(Debug, "Created dropping rate limited source. rate (%.2f/s), burst (%d).", rate, burst)
+	ret.Start()
+	return ret
+}
+
+// RateLimitedSink returns a [Sink] that calls the provided sink at no more than rate items per second, with bursts of
+// up to burst items. Calls exceeding the rate wait for their turn. The rate is shared by every Source observed by the
+// returned sink. RateLimitedSink panics if rate or burst is not positive.
+func RateLimitedSink[T any](sink func(T) error, rate float64, burst int) Sink[T] {
+	id := fmt.Sprintf("rateLimit(%p)", sink)
+	bucket := newTokenBucket("RateLimitedSink", rate, burst)
+	return func(item T) error {
+		if wait := bucket.reserve(); wait > 0 {
+			logger(Debug, id, "Throttling item (%.10v) for %s.", item, wait)
+			sleep(wait)
+		}
+		return sink(item)
+	}
+}
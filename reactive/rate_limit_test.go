@@ -0,0 +1,94 @@
+package reactive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRateLimit_DelaysItems(t *testing.T) {
+	fake := useFakeClock(t)
+	c := make(chan int)
+	source := FromChan(c)
+	limited := RateLimit(source, 10, 2)
+	results := make(chan int, 10)
+	limited.Observe(func(item int) error {
+		results <- item
+		return nil
+	})
+	source.Start()
+	c <- 1
+	c <- 2
+	assert.Equal(t, 1, <-results)
+	assert.Equal(t, 2, <-results)
+	go func() {
+		c <- 3
+		close(c)
+	}()
+	fake.AwaitWaiters(1)
+	assert.Empty(t, results)
+	fake.Advance(100 * time.Millisecond)
+	source.AwaitCompletion()
+	assert.Equal(t, 3, <-results)
+}
+
+func TestRateLimitDropping_DropsItems(t *testing.T) {
+	fake := useFakeClock(t)
+	pos := 0
+	source := FromGenerator(func() (*int, error) {
+		pos++
+		if pos == 4 {
+			fake.Advance(time.Second)
+		}
+		if pos > 5 {
+			return nil, &GeneratorFinished{}
+		}
+		ret := pos
+		return &ret, nil
+	})
+	limited := RateLimitDropping(source, 1, 2)
+	var results []int
+	limited.Observe(func(item int) error {
+		results = append(results, item)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, []int{1, 2, 4}, results)
+}
+
+func TestRateLimitedSink_SharesRate(t *testing.T) {
+	fake := useFakeClock(t)
+	calls := 0
+	underTest := RateLimitedSink(func(item int) error {
+		calls++
+		return nil
+	}, 2, 1)
+	assert.NoError(t, underTest(1))
+	done := make(chan error)
+	go func() {
+		done <- underTest(2)
+	}()
+	fake.AwaitWaiters(1)
+	assert.Equal(t, 1, calls)
+	fake.Advance(500 * time.Millisecond)
+	assert.NoError(t, <-done)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRateLimit_RejectsInvalidArguments(t *testing.T) {
+	sink := func(int) error { return nil }
+	assert.PanicsWithValue(t, "reactive: RateLimit rate must be positive, found 0", func() {
+		RateLimit(FromChan(make(chan int)), 0, 1)
+	})
+	assert.PanicsWithValue(t, "reactive: RateLimitDropping rate must be positive, found -1", func() {
+		RateLimitDropping(FromChan(make(chan int)), -1, 1)
+	})
+	assert.PanicsWithValue(t, "reactive: RateLimitedSink rate must be positive, found NaN", func() {
+		RateLimitedSink(sink, math.NaN(), 1)
+	})
+	assert.PanicsWithValue(t, "reactive: RateLimitedSink burst must be positive, found 0", func() {
+		RateLimitedSink(sink, 1, 0)
+	})
+}
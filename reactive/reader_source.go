@@ -0,0 +1,190 @@
+package reactive
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// FromReader returns a [CancellableSource] of the tokens read from the provided reader, split by the provided
+// [bufio.SplitFunc]. A nil split reads lines ([bufio.ScanLines]). The source completes at the end of the reader; a
+// read error is logged and also completes the source.
+func FromReader(r io.Reader, split bufio.SplitFunc) CancellableSource[[]byte] {
+	scanner := bufio.NewScanner(r)
+	if split != nil {
+		scanner.Split(split)
+	}
+	var ret CancellableSource[[]byte]
+	ret = FromGenerator(func() (*[]byte, error) {
+		if scanner.Scan() {
+			token := bytes.Clone(scanner.Bytes())
+			return &token, nil
+		}
+		if err := scanner.Err(); err != nil {
+			logger(Warning, ret, "Error reading from reader (%p): [%v]", r, err)
+		}
+		return nil, &GeneratorFinished{}
+	})
+	return ret
+}
+
+// FromLines is similar to [FromReader], but returns a [CancellableSource] of the lines read, without line endings.
+func FromLines(r io.Reader) CancellableSource[string] {
+	scanner := bufio.NewScanner(r)
+	var ret CancellableSource[string]
+	ret = FromGenerator(func() (*string, error) {
+		if scanner.Scan() {
+			line := scanner.Text()
+			return &line, nil
+		}
+		if err := scanner.Err(); err != nil {
+			logger(Warning, ret, "Error reading from reader (%p): [%v]", r, err)
+		}
+		return nil, &GeneratorFinished{}
+	})
+	return ret
+}
+
+// TailLine is a line read by [TailFile].
+type TailLine struct {
+	// Text is the line, without its line ending.
+	Text string
+	// Offset is the byte offset just after this line. Pass it as [TailOptions] Offset to resume after this line.
+	Offset int64
+}
+
+// TailOptions configures [TailFile].
+type TailOptions struct {
+	// Offset is the byte offset to start reading from.
+	Offset int64
+	// PollInterval is how long to wait for new data once the end of the file is reached. Defaults to 250ms.
+	PollInterval time.Duration
+}
+
+type tailer struct {
+	path    string
+	file    *os.File
+	reader  *bufio.Reader
+	offset  int64
+	pending []byte
+}
+
+// TailFile returns a [CancellableSource] of the lines of the file at path, following data appended to it like
+// "tail -F". Once the end of the file is reached the file is polled for new data.
+//   - If the file shrinks below the current offset it is treated as truncated and read again from the start.
+//   - If the path is renamed and recreated (log rotation) the remainder of the old file is read, then the new file is
+//     read from the start.
+//   - If the file does not exist yet it is polled for until it does.
+//
+// A trailing partial line is held until its line ending is written, or until the file is rotated away.
+func TailFile(path string, options TailOptions) CancellableSource[TailLine] {
+	if options.PollInterval <= 0 {
+		options.PollInterval = 250 * time.Millisecond
+	}
+	t := &tailer{path: path, offset: options.Offset}
+	ret := FromGeneratorWithPolling(t.next, ExponentialBackoff(options.PollInterval, 10*time.Second),
+		ConstantBackoff(options.PollInterval))
+	ret.UponClose(t.close)
+	return ret
+}
+
+func (t *tailer) next() (*TailLine, error) {
+	if t.file == nil {
+		if err := t.open(); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+	chunk, err := t.reader.ReadBytes('\n')
+	t.pending = append(t.pending, chunk...)
+	if err == nil {
+		return t.emit(), nil
+	}
+	if !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return t.checkFile()
+}
+
+func (t *tailer) emit() *TailLine {
+	t.offset += int64(len(t.pending))
+	line := TailLine{
+		Text:   string(bytes.TrimRight(t.pending, "\r\n")),
+		Offset: t.offset,
+	}
+	t.pending = nil
+	return &line
+}
+
+// checkFile is called at the end of the file to detect truncation and rotation.
+func (t *tailer) checkFile() (*TailLine, error) {
+	current, err := t.file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	latest, err := os.Stat(t.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if latest == nil || !os.SameFile(current, latest) {
+		if latest == nil {
+			// rotated away, but not recreated yet
+			return nil, nil
+		}
+		logger(Info, t.path, "File rotated. Reading the new file from the start.")
+		var line *TailLine
+		if len(t.pending) > 0 {
+			line = t.emit()
+		}
+		t.close()
+		t.offset = 0
+		return line, nil
+	}
+	if current.Size() < t.offset+int64(len(t.pending)) {
+		logger(Info, t.path, "File truncated. Reading from the start.")
+		t.offset = 0
+		t.pending = nil
+		if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		t.reader.Reset(t.file)
+	}
+	return nil, nil
+}
+
+func (t *tailer) open() error {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	if info.Size() < t.offset {
+		logger(Info, t.path, "File is shorter than offset %d. Reading from the start.", t.offset)
+		t.offset = 0
+	}
+	if _, err := file.Seek(t.offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return err
+	}
+	logger(Debug, t.path, "Opened file at offset %d.", t.offset)
+	t.file = file
+	t.reader = bufio.NewReader(file)
+	return nil
+}
+
+func (t *tailer) close() {
+	if t.file != nil {
+		logger(Debug, t.path, "Closing file.")
+		_ = t.file.Close()
+		t.file = nil
+	}
+}
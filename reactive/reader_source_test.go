@@ -0,0 +1,139 @@
+package reactive
+
+import (
+	"bufio"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestFromReader_SplitsTokens(t *testing.T) {
+	underTest := FromReader(strings.NewReader("foobar test\nfizzbuzz"), bufio.ScanWords)
+	var results []string
+	underTest.Observe(func(token []byte) error {
+		results = append(results, string(token))
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"foobar", "test", "fizzbuzz"}, results)
+}
+
+func TestFromReader_DefaultsToLines(t *testing.T) {
+	underTest := FromReader(strings.NewReader("foobar test\nfizzbuzz\n"), nil)
+	var results []string
+	underTest.Observe(func(token []byte) error {
+		results = append(results, string(token))
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"foobar test", "fizzbuzz"}, results)
+}
+
+func TestFromReader_CompletesOnError(t *testing.T) {
+	underTest := FromReader(iotest.ErrReader(errors.New("test error")), nil)
+	underTest.Start()
+	underTest.AwaitCompletion()
+	lines := FromLines(iotest.ErrReader(errors.New("test error")))
+	lines.Start()
+	lines.AwaitCompletion()
+}
+
+func TestFromLines_HappyPath(t *testing.T) {
+	underTest := FromLines(strings.NewReader("foobar\r\ntest\n\nfizzbuzz"))
+	var results []string
+	underTest.Observe(func(line string) error {
+		results = append(results, line)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"foobar", "test", "", "fizzbuzz"}, results)
+}
+
+func appendToFile(t *testing.T, path string, data string) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = file.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
+
+func tail(t *testing.T, path string, offset int64) (CancellableSource[TailLine], chan TailLine) {
+	underTest := TailFile(path, TailOptions{Offset: offset, PollInterval: time.Millisecond})
+	lines := make(chan TailLine, 100)
+	underTest.Observe(func(line TailLine) error {
+		lines <- line
+		return nil
+	})
+	underTest.Start()
+	t.Cleanup(func() {
+		_ = underTest.Cancel()
+		underTest.AwaitCompletion()
+	})
+	return underTest, lines
+}
+
+func receive(t *testing.T, lines chan TailLine) TailLine {
+	select {
+	case line := <-lines:
+		return line
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for a line")
+	}
+	return TailLine{}
+}
+
+func TestTailFile_FollowsAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	_, lines := tail(t, path, 0)
+	appendToFile(t, path, "foobar\ntes")
+	assert.Equal(t, TailLine{Text: "foobar", Offset: 7}, receive(t, lines))
+	appendToFile(t, path, "t\r\n")
+	assert.Equal(t, TailLine{Text: "test", Offset: 13}, receive(t, lines))
+}
+
+func TestTailFile_ResumesFromOffset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	appendToFile(t, path, "foobar\ntest\n")
+	_, lines := tail(t, path, 7)
+	assert.Equal(t, TailLine{Text: "test", Offset: 12}, receive(t, lines))
+}
+
+func TestTailFile_OffsetPastEndRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	appendToFile(t, path, "foobar\n")
+	_, lines := tail(t, path, 100)
+	assert.Equal(t, TailLine{Text: "foobar", Offset: 7}, receive(t, lines))
+}
+
+func TestTailFile_HandlesTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	appendToFile(t, path, "foobar\n")
+	_, lines := tail(t, path, 0)
+	assert.Equal(t, "foobar", receive(t, lines).Text)
+	require.NoError(t, os.Truncate(path, 0))
+	time.Sleep(20 * time.Millisecond)
+	appendToFile(t, path, "test\n")
+	assert.Equal(t, TailLine{Text: "test", Offset: 5}, receive(t, lines))
+}
+
+func TestTailFile_HandlesRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	appendToFile(t, path, "foobar\n")
+	_, lines := tail(t, path, 0)
+	assert.Equal(t, "foobar", receive(t, lines).Text)
+	appendToFile(t, path, "partial")
+	require.NoError(t, os.Rename(path, path+".1"))
+	time.Sleep(20 * time.Millisecond)
+	appendToFile(t, path, "fizzbuzz\n")
+	assert.Equal(t, "partial", receive(t, lines).Text)
+	assert.Equal(t, TailLine{Text: "fizzbuzz", Offset: 9}, receive(t, lines))
+}
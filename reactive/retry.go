@@ -0,0 +1,131 @@
+package reactive
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy describes how a failing sink or mapper is retried. Between attempts the policy waits as long as its
+// Backoff decides or, when Backoff is nil, m*2^e, where m is BackoffMultiplier and e is the number of failed attempts,
+// capped at MaxBackoff. When the failure wraps an error with a RetryAfter() time.Duration method, such as
+// [HTTPStatusError], the policy waits at least that long, but no longer than MaxBackoff.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Values below 2 disable retries.
+	MaxAttempts int
+	// Backoff decides the wait between attempts, given the number of failed attempts. It replaces BackoffMultiplier
+	// and Jitter when set. One Backoff is shared by every call of the policy, so it is reset after each retried call.
+	Backoff Backoff
+	// BackoffMultiplier is the multiplier m in m*2^e.
+	BackoffMultiplier time.Duration
+	// MaxBackoff is the longest wait between attempts, unless Backoff is set. Zero means no limit. It always caps the
+	// delay requested by a RetryAfter method.
+	MaxBackoff time.Duration
+	// Jitter waits a random duration between zero and the computed backoff ("full jitter") when true.
+	Jitter bool
+	// Retryable reports whether an error should be retried. When nil every error is retried.
+	Retryable func(error) bool
+}
+
+// RetryError is returned when a [RetryPolicy] gives up. It wraps the error from the last attempt.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+// Error implements the error interface
+func (r *RetryError) Error() string {
+	return fmt.Sprintf("failed after %d attempt(s): %v", r.Attempts, r.Err)
+}
+
+// Unwrap returns the error from the last attempt.
+func (r *RetryError) Unwrap() error {
+	return r.Err
+}
+
+// exponentialDelay returns multiplier*2^exponent, capped at maxDelay when maxDelay is positive. Delays too long to
+// represent are capped at the longest time.Duration.
+func exponentialDelay(multiplier time.Duration, maxDelay time.Duration, exponent int) time.Duration {
+	delay := float64(multiplier) * math.Pow(2.0, float64(exponent))
+	if maxDelay > 0 && delay > float64(maxDelay) {
+		return maxDelay
+	}
+	if delay >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(delay)
+}
+
+func (p RetryPolicy) backoff(failures int) time.Duration {
+	if p.Backoff != nil {
+		return p.Backoff.Next(failures)
+	}
+	wait := exponentialDelay(p.BackoffMultiplier, p.MaxBackoff, failures)
+	if p.Jitter && wait > 0 {
+		wait = rand.N(wait)
+	}
+	return wait
+}
+
+// do runs the operation until it succeeds or the policy gives up.
+func (p RetryPolicy) do(id interface{}, operation func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := operation()
+		if err == nil {
+			p.reset(attempt)
+			return nil
+		}
+		if attempt >= p.MaxAttempts || (p.Retryable != nil && !p.Retryable(err)) {
+			p.reset(attempt)
+			return &RetryError{Attempts: attempt, Err: err}
+		}
+		wait := p.backoff(attempt)
+		var hinted interface{ RetryAfter() time.Duration }
+		if errors.As(err, &hinted) && hinted.RetryAfter() > wait {
+			wait = hinted.RetryAfter()
+			if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+				logger(Debug, id, "Capping requested retry delay (%s) at %s.", wait, p.MaxBackoff)
+				wait = p.MaxBackoff
+			}
+		}
+		logger(Info, id, "Attempt %d failed: [%v]. Retrying in %s.", attempt, err, wait)
+		sleep(wait)
+	}
+}
+
+// reset resets the Backoff once a call that was retried has finished.
+func (p RetryPolicy) reset(attempts int) {
+	if p.Backoff != nil && attempts > 1 {
+		p.Backoff.Reset()
+	}
+}
+
+// WithRetry returns a [Sink] that calls the provided sink, retrying failures according to the provided [RetryPolicy].
+// When the policy gives up the returned sink returns a [RetryError].
+func WithRetry[T any](sink func(T) error, policy RetryPolicy) Sink[T] {
+	id := fmt.Sprintf("retry(%p)", sink)
+	return func(item T) error {
+		return policy.do(id, func() error {
+			return sink(item)
+		})
+	}
+}
+
+// MapWithRetry is similar to [Map], but retries mapper failures according to the provided [RetryPolicy]. Items are
+// only dropped once the policy gives up.
+//
+// The returned Source is already started.
+func MapWithRetry[T any, V any](source Source[T], mapper func(T) (V, error), policy RetryPolicy) Source[V] {
+	id := fmt.Sprintf("retry(%p)", mapper)
+	return Map(source, func(item T) (V, error) {
+		var transformed V
+		err := policy.do(id, func() error {
+			var err error
+			transformed, err = mapper(item)
+			return err
+		})
+		return transformed, err
+	})
+}
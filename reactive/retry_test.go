@@ -0,0 +1,125 @@
+package reactive
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWithRetry_EventuallySucceeds(t *testing.T) {
+	attempts := 0
+	sink := WithRetry(func(item string) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("test error")
+		}
+		return nil
+	}, RetryPolicy{MaxAttempts: 5, BackoffMultiplier: time.Millisecond})
+	assert.NoError(t, sink("test"))
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithRetry_GivesUp(t *testing.T) {
+	attempts := 0
+	expected := errors.New("test error")
+	sink := WithRetry(func(item string) error {
+		attempts++
+		return expected
+	}, RetryPolicy{MaxAttempts: 3, BackoffMultiplier: time.Millisecond, Jitter: true})
+	err := sink("test")
+	assert.ErrorIs(t, err, expected)
+	var retryError *RetryError
+	assert.ErrorAs(t, err, &retryError)
+	assert.Equal(t, 3, retryError.Attempts)
+	assert.Equal(t, 3, attempts)
+	assert.NotEmpty(t, err.Error())
+}
+
+func TestWithRetry_OnlyRetriesRetryableErrors(t *testing.T) {
+	attempts := 0
+	permanent := errors.New("permanent")
+	sink := WithRetry(func(item string) error {
+		attempts++
+		return permanent
+	}, RetryPolicy{
+		MaxAttempts: 3,
+		Retryable: func(err error) bool {
+			return !errors.Is(err, permanent)
+		},
+	})
+	assert.ErrorIs(t, sink("test"), permanent)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BackoffMultiplier: 10 * time.Millisecond, MaxBackoff: 100 * time.Millisecond}
+	assert.Equal(t, 20*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 80*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(10))
+	policy.Jitter = true
+	assert.LessOrEqual(t, policy.backoff(10), 100*time.Millisecond)
+}
+
+func TestWithRetry_UsesBackoff(t *testing.T) {
+	fake := useFakeClock(t)
+	attempts := 0
+	sink := WithRetry(func(item string) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("test error")
+		}
+		return nil
+	}, RetryPolicy{MaxAttempts: 3, Backoff: LinearBackoff(time.Second, time.Second, 0), BackoffMultiplier: time.Hour})
+	result := make(chan error)
+	go func() {
+		result <- sink("test")
+	}()
+	fake.AwaitWaiters(1)
+	fake.Advance(time.Second)
+	fake.AwaitWaiters(1)
+	fake.Advance(2 * time.Second)
+	assert.NoError(t, <-result)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithRetry_CapsRetryAfterAtMaxBackoff(t *testing.T) {
+	fake := useFakeClock(t)
+	attempts := 0
+	sink := WithRetry(func(item string) error {
+		attempts++
+		if attempts < 2 {
+			return &HTTPStatusError{StatusCode: 503, Wait: time.Hour}
+		}
+		return nil
+	}, RetryPolicy{MaxAttempts: 2, BackoffMultiplier: time.Millisecond, MaxBackoff: time.Second})
+	result := make(chan error)
+	go func() {
+		result <- sink("test")
+	}()
+	fake.AwaitWaiters(1)
+	fake.Advance(time.Second)
+	assert.NoError(t, <-result)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestMapWithRetry_HappyPath(t *testing.T) {
+	source := Just(1, 2)
+	failures := map[int]int{1: 2, 2: 5}
+	mapped := MapWithRetry(source, func(item int) (string, error) {
+		if failures[item] > 0 {
+			failures[item]--
+			return "", errors.New("test error")
+		}
+		return strconv.Itoa(item), nil
+	}, RetryPolicy{MaxAttempts: 3})
+	var results []string
+	mapped.Observe(func(item string) error {
+		results = append(results, item)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, []string{"1"}, results)
+}
@@ -0,0 +1,246 @@
+package reactive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotatingFileOptions configures a [RotatingFile]. Zero values disable the corresponding rotation trigger.
+type RotatingFileOptions struct {
+	// MaxBytes rotates the file before a record would grow it beyond this size. A record larger than MaxBytes is
+	// written to a file of its own.
+	MaxBytes int64
+	// MaxItems rotates the file once it holds this many records.
+	MaxItems int
+	// Interval rotates the file once it has been open this long, whether or not records are still arriving. The next
+	// file is opened when the next record is written.
+	Interval time.Duration
+	// Compress gzips each rolled file, replacing it with a file of the same name ending in ".gz". Files are compressed
+	// without holding up writes to the next file.
+	Compress bool
+}
+
+// RotatingFile writes records to a series of files in one directory, starting a new file whenever one of the
+// configured limits is reached. Files are named from a pattern: the last "*" is replaced with a timestamp and
+// sequence number, which are appended if the pattern holds no "*". Records are buffered; each file is flushed and
+// synced to disk before it is closed, so a record is never split across files.
+//
+// A RotatingFile is safe for concurrent use.
+type RotatingFile struct {
+	dir      string
+	pattern  string
+	options  RotatingFileOptions
+	lock     sync.Mutex
+	file     *os.File
+	writer   *bufio.Writer
+	bytes    int64
+	items    int
+	openedAt time.Time
+	timer    Stopper
+	sequence int
+	closed   bool
+	// compressing tracks rolled files being compressed, which Close waits for.
+	compressing sync.WaitGroup
+}
+
+// RotatingFileSink returns a [RotatingFile] writing into dir, which is created if needed. Files are created lazily,
+// when the first record for them is written, so no empty files are left behind.
+func RotatingFileSink(dir string, pattern string, options RotatingFileOptions) (*RotatingFile, error) {
+	if strings.ContainsRune(pattern, os.PathSeparator) {
+		return nil, fmt.Errorf("pattern (%s) contains a path separator", pattern)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	ret := &RotatingFile{
+		dir:     dir,
+		pattern: pattern,
+		options: options,
+	}
+	logger(Debug, ret, "Created rotating file sink: %+v", options)
+	return ret, nil
+}
+
+func (r *RotatingFile) String() string {
+	return fmt.Sprintf("rotating(%s)", filepath.Join(r.dir, r.pattern))
+}
+
+// Write appends the record to the current file, rotating first if a limit has been reached. It may be passed to
+// [Source.Observe]. Writing to a closed RotatingFile returns [os.ErrClosed]. A rolled file that fails to compress is
+// logged.
+func (r *RotatingFile) Write(record []byte) error {
+	rolled, err := r.write(record)
+	r.compressAsync(rolled)
+	return err
+}
+
+func (r *RotatingFile) write(record []byte) (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return "", os.ErrClosed
+	}
+	rolled := ""
+	if r.file != nil && r.full(len(record)) {
+		var err error
+		if rolled, err = r.rotate(); err != nil {
+			return "", err
+		}
+	}
+	if r.file == nil {
+		if err := r.open(); err != nil {
+			return rolled, err
+		}
+	}
+	written, err := r.writer.Write(record)
+	r.bytes += int64(written)
+	r.items++
+	return rolled, err
+}
+
+// Attach observes the provided [Source] with Write and closes the RotatingFile once the Source closes. The last file
+// is flushed, synced and closed before the Source's AwaitCompletion returns. A failed close is logged.
+func (r *RotatingFile) Attach(source Source[[]byte]) {
+	source.Observe(r.Write)
+	source.UponClose(func() {
+		if err := r.Close(); err != nil {
+			logger(Warning, source, "Failed to close rotating file sink (%s): [%v]", r, err)
+		}
+	})
+}
+
+// Close flushes, syncs and closes the current file, and waits for rolled files to be compressed. Closing an already
+// closed RotatingFile does nothing.
+func (r *RotatingFile) Close() error {
+	r.lock.Lock()
+	if r.closed {
+		r.lock.Unlock()
+		return nil
+	}
+	r.closed = true
+	var rolled string
+	var err error
+	if r.file != nil {
+		rolled, err = r.rotate()
+	}
+	r.lock.Unlock()
+	if rolled != "" {
+		err = compress(rolled)
+		r.compressing.Done()
+	}
+	r.compressing.Wait()
+	return err
+}
+
+// expire rotates the file when it has been open for the interval, unless it was already rolled.
+func (r *RotatingFile) expire(file *os.File) {
+	r.lock.Lock()
+	if r.file != file {
+		r.lock.Unlock()
+		return
+	}
+	logger(Debug, r, "Interval (%s) elapsed.", r.options.Interval)
+	rolled, err := r.rotate()
+	r.lock.Unlock()
+	if err != nil {
+		logger(Warning, r, "Failed to roll file (%s): [%v]", file.Name(), err)
+	}
+	r.compressAsync(rolled)
+}
+
+// compressAsync compresses the rolled file, if any, on its own go routine, logging failures.
+func (r *RotatingFile) compressAsync(rolled string) {
+	if rolled == "" {
+		return
+	}
+	go func() {
+		defer r.compressing.Done()
+		if err := compress(rolled); err != nil {
+			logger(Warning, r, "Failed to compress file (%s): [%v]", rolled, err)
+		}
+	}()
+}
+
+// full must be called while holding the lock.
+func (r *RotatingFile) full(size int) bool {
+	if r.options.MaxBytes > 0 && r.bytes+int64(size) > r.options.MaxBytes {
+		return true
+	}
+	if r.options.MaxItems > 0 && r.items >= r.options.MaxItems {
+		return true
+	}
+	return r.options.Interval > 0 && clock.Now().Sub(r.openedAt) >= r.options.Interval
+}
+
+// open must be called while holding the lock.
+func (r *RotatingFile) open() error {
+	now := clock.Now()
+	r.sequence++
+	name := fmt.Sprintf("%s-%04d", now.UTC().Format("20060102T150405"), r.sequence)
+	if index := strings.LastIndex(r.pattern, "*"); index >= 0 {
+		name = r.pattern[:index] + name + r.pattern[index+1:]
+	} else {
+		name = r.pattern + name
+	}
+	file, err := os.OpenFile(filepath.Join(r.dir, name), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	logger(Debug, r, "Opened file (%s).", file.Name())
+	r.file = file
+	r.writer = bufio.NewWriter(file)
+	r.bytes = 0
+	r.items = 0
+	r.openedAt = now
+	if r.options.Interval > 0 {
+		r.timer = clock.AfterFunc(r.options.Interval, func() {
+			r.expire(file)
+		})
+	}
+	return nil
+}
+
+// rotate must be called while holding the lock. It returns the name of the rolled file when it should be compressed,
+// counting it in compressing.
+func (r *RotatingFile) rotate() (string, error) {
+	file := r.file
+	r.file = nil
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	logger(Debug, r, "Rolling file (%s) with %d records.", file.Name(), r.items)
+	err := errors.Join(r.writer.Flush(), file.Sync(), file.Close())
+	if err != nil || !r.options.Compress {
+		return "", err
+	}
+	r.compressing.Add(1)
+	return file.Name(), nil
+}
+
+func compress(name string) error {
+	source, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	target, err := os.OpenFile(name+".gz", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	writer := gzip.NewWriter(target)
+	_, err = io.Copy(writer, source)
+	err = errors.Join(err, writer.Close(), target.Sync(), target.Close())
+	if err != nil {
+		return err
+	}
+	return os.Remove(name)
+}
@@ -0,0 +1,139 @@
+package reactive
+
+import (
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readRotated(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var ret []string
+	for _, entry := range entries {
+		file, err := os.Open(filepath.Join(dir, entry.Name()))
+		require.NoError(t, err)
+		var reader io.Reader = file
+		if strings.HasSuffix(entry.Name(), ".gz") {
+			reader, err = gzip.NewReader(file)
+			require.NoError(t, err)
+		}
+		contents, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		ret = append(ret, string(contents))
+	}
+	return ret
+}
+
+func lines(items ...string) Source[[]byte] {
+	var records [][]byte
+	for _, item := range items {
+		records = append(records, []byte(item+"\n"))
+	}
+	return Just(records...)
+}
+
+func TestRotatingFileSink_RotatesOnItemCount(t *testing.T) {
+	dir := t.TempDir()
+	underTest, err := RotatingFileSink(dir, "out-*.log", RotatingFileOptions{MaxItems: 2})
+	require.NoError(t, err)
+	source := lines("a", "b", "c", "d", "e")
+	underTest.Attach(source)
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, []string{"a\nb\n", "c\nd\n", "e\n"}, readRotated(t, dir))
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		assert.Regexp(t, `^out-\d{8}T\d{6}-\d{4}\.log$`, entry.Name())
+	}
+}
+
+func TestRotatingFileSink_RotatesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	underTest, err := RotatingFileSink(dir, "out", RotatingFileOptions{MaxBytes: 8})
+	require.NoError(t, err)
+	source := lines("foo", "bar", "foobar", "fizzbuzz", "a")
+	underTest.Attach(source)
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, []string{"foo\nbar\n", "foobar\n", "fizzbuzz\n", "a\n"}, readRotated(t, dir))
+}
+
+func TestRotatingFileSink_RotatesOnInterval(t *testing.T) {
+	fake := useFakeClock(t)
+	dir := t.TempDir()
+	underTest, err := RotatingFileSink(dir, "*.log", RotatingFileOptions{Interval: time.Minute})
+	require.NoError(t, err)
+	require.NoError(t, underTest.Write([]byte("a\n")))
+	fake.Advance(30 * time.Second)
+	require.NoError(t, underTest.Write([]byte("b\n")))
+	fake.Advance(30 * time.Second)
+	require.NoError(t, underTest.Write([]byte("c\n")))
+	require.NoError(t, underTest.Close())
+	assert.Equal(t, []string{"a\nb\n", "c\n"}, readRotated(t, dir))
+}
+
+func TestRotatingFileSink_RotatesIdleFileOnInterval(t *testing.T) {
+	fake := useFakeClock(t)
+	dir := t.TempDir()
+	underTest, err := RotatingFileSink(dir, "*.log", RotatingFileOptions{Interval: time.Minute, Compress: true})
+	require.NoError(t, err)
+	require.NoError(t, underTest.Write([]byte("a\n")))
+	fake.Advance(time.Minute)
+	require.NoError(t, underTest.Write([]byte("b\n")))
+	require.NoError(t, underTest.Close())
+	assert.Equal(t, []string{"a\n", "b\n"}, readRotated(t, dir))
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		assert.True(t, strings.HasSuffix(entry.Name(), ".log.gz"), entry.Name())
+	}
+}
+
+func TestRotatingFileSink_FlushesIdleFileOnInterval(t *testing.T) {
+	fake := useFakeClock(t)
+	dir := t.TempDir()
+	underTest, err := RotatingFileSink(dir, "*.log", RotatingFileOptions{Interval: time.Minute})
+	require.NoError(t, err)
+	require.NoError(t, underTest.Write([]byte("a\n")))
+	assert.Equal(t, []string{""}, readRotated(t, dir))
+	fake.Advance(time.Minute)
+	assert.Equal(t, []string{"a\n"}, readRotated(t, dir))
+	require.NoError(t, underTest.Close())
+}
+
+func TestRotatingFileSink_CompressesRolledFiles(t *testing.T) {
+	dir := t.TempDir()
+	underTest, err := RotatingFileSink(dir, "out-*.log", RotatingFileOptions{MaxItems: 2, Compress: true})
+	require.NoError(t, err)
+	source := lines("a", "b", "c")
+	underTest.Attach(source)
+	source.Start()
+	source.AwaitCompletion()
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		assert.True(t, strings.HasSuffix(entry.Name(), ".log.gz"), entry.Name())
+	}
+	assert.Equal(t, []string{"a\nb\n", "c\n"}, readRotated(t, dir))
+}
+
+func TestRotatingFileSink_RejectsWritesAfterClose(t *testing.T) {
+	dir := t.TempDir()
+	underTest, err := RotatingFileSink(dir, "out", RotatingFileOptions{})
+	require.NoError(t, err)
+	require.NoError(t, underTest.Close())
+	assert.ErrorIs(t, underTest.Write([]byte("a")), os.ErrClosed)
+	assert.NoError(t, underTest.Close())
+	assert.Empty(t, readRotated(t, dir))
+}
+
+func TestRotatingFileSink_RejectsPathSeparators(t *testing.T) {
+	_, err := RotatingFileSink(t.TempDir(), "nested/out", RotatingFileOptions{})
+	assert.Error(t, err)
+}
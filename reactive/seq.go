@@ -0,0 +1,101 @@
+package reactive
+
+import (
+	"context"
+	"iter"
+)
+
+// KeyValue is a pair of values from an [iter.Seq2]. See [FromSeq2].
+type KeyValue[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// FromSeq returns a [CancellableSource] pumping the values of the provided iterator. The iterator is consumed
+// after Start, and stops early if the source is cancelled.
+func FromSeq[T any](seq iter.Seq[T]) CancellableSource[T] {
+	ret := newSubjectSource[T]()
+	ret.log(Verbose, "Creating Source from iterator (%p).", seq)
+	ret.setStart(func() {
+		for item := range seq {
+			if ret.cancelled() {
+				return
+			}
+			ret.pump(item)
+		}
+	})
+	return ret
+}
+
+// FromSeq2 returns a [CancellableSource] pumping the pairs of the provided iterator as [KeyValue] items. See
+// [FromSeq].
+func FromSeq2[K any, V any](seq iter.Seq2[K, V]) CancellableSource[KeyValue[K, V]] {
+	return FromSeq(func(yield func(KeyValue[K, V]) bool) {
+		for key, value := range seq {
+			if !yield(KeyValue[K, V]{Key: key, Value: value}) {
+				return
+			}
+		}
+	})
+}
+
+// ToSeq returns an iterator over the items of the provided [Source], so the Source can be consumed with a for range
+// loop. Iterating observes and starts the Source, then yields each item as it is pumped. The Source waits for the
+// loop body to finish with an item before pumping the next one.
+//
+// Breaking out of the loop early stops observing the Source and, if it is a [CancellableSource], cancels it.
+//
+// The returned iterator should only be used once.
+func ToSeq[T any](source Source[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range ToSeqErr(context.Background(), source) {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// ToSeqErr is similar to [ToSeq], but also stops when the provided context is done. The context's error is then
+// yielded with the zero value of T as the final pair.
+func ToSeqErr[T any](ctx context.Context, source Source[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		items := make(chan T)
+		stop := make(chan struct{})
+		finished := make(chan struct{})
+		source.Observe(func(item T) error {
+			select {
+			case items <- item:
+			case <-stop:
+				logger(Debug, source, "Iteration stopped. Skipping item (%.10v).", item)
+			}
+			return nil
+		})
+		source.UponClose(func() {
+			close(finished)
+		})
+		source.Start()
+		defer close(stop)
+		defer cancelIfPossible(source)
+		for {
+			select {
+			case item := <-items:
+				if !yield(item, nil) {
+					return
+				}
+			case <-finished:
+				return
+			case <-ctx.Done():
+				var zero T
+				yield(zero, ctx.Err())
+				return
+			}
+		}
+	}
+}
+
+func cancelIfPossible[T any](source Source[T]) {
+	if cancellable, ok := source.(CancellableSource[T]); ok {
+		_ = cancellable.Cancel()
+	}
+}
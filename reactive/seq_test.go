@@ -0,0 +1,115 @@
+package reactive
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestFromSeq_HappyPath(t *testing.T) {
+	underTest := FromSeq(slices.Values([]string{"foobar", "test", "fizzbuzz"}))
+	var results []string
+	underTest.Observe(func(item string) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"foobar", "test", "fizzbuzz"}, results)
+}
+
+func TestFromSeq_Cancelable(t *testing.T) {
+	var underTest CancellableSource[int]
+	pulled := 0
+	underTest = FromSeq(func(yield func(int) bool) {
+		for {
+			pulled++
+			if !yield(pulled) {
+				return
+			}
+		}
+	})
+	underTest.Observe(func(item int) error {
+		if item == 3 {
+			return underTest.Cancel()
+		}
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, 4, pulled)
+}
+
+func TestFromSeq2_HappyPath(t *testing.T) {
+	underTest := FromSeq2(maps.All(map[string]int{"test": 1}))
+	var results []KeyValue[string, int]
+	underTest.Observe(func(item KeyValue[string, int]) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []KeyValue[string, int]{{Key: "test", Value: 1}}, results)
+}
+
+func TestFromSeq2_StopsEarly(t *testing.T) {
+	var results []int
+	for item := range ToSeq(FromSeq2(slices.All([]string{"a", "b", "c"}))) {
+		results = append(results, item.Key)
+		if item.Key == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1}, results)
+}
+
+func TestToSeq_HappyPath(t *testing.T) {
+	var results []string
+	for item := range ToSeq(Just("foobar", "test", "fizzbuzz")) {
+		results = append(results, item)
+	}
+	assert.Equal(t, []string{"foobar", "test", "fizzbuzz"}, results)
+}
+
+func TestToSeq_BreakCancels(t *testing.T) {
+	source := Range(0, 1000)
+	var results []int
+	for item := range ToSeq[int](source) {
+		results = append(results, item)
+		if item == 2 {
+			break
+		}
+	}
+	source.AwaitCompletion()
+	assert.Equal(t, []int{0, 1, 2}, results)
+}
+
+func TestToSeq_BreakReleasesNonCancellableSources(t *testing.T) {
+	source := Just(1, 2, 3)
+	for range ToSeq(source) {
+		break
+	}
+	source.AwaitCompletion()
+}
+
+func TestToSeqErr_StopsOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source := Interval(0)
+	var errs []error
+	count := 0
+	for _, err := range ToSeqErr[int64](ctx, source) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		count++
+		if count == 5 {
+			cancel()
+		}
+	}
+	source.AwaitCompletion()
+	assert.Equal(t, []error{context.Canceled}, errs)
+}
@@ -1,11 +1,12 @@
 package reactive
 
 // Source is a producer of items. A Source can be based on a generator function ([FromGenerator],
-// [FromGeneratorWithExponentialBackoff]), a channel ([FromChan])
+// [FromGeneratorWithExponentialBackoff], [FromGeneratorWithBackoff]), a channel ([FromChan])
 // or a literal ([Just]).
 type Source[T any] interface {
 	// UponClose registers a hook to run then this source shuts down.
 	// All registered functions will complete before AwaitCompletion unblocks.
+	// A hook registered after the source shut down runs right away.
 	UponClose(func())
 	// Observe registers a sink that will observe each item that flows through this source.
 	// Each sink will be called in its own go routine.
@@ -13,7 +14,7 @@ type Source[T any] interface {
 	// Start begins pumping items through the source.
 	// Generators start polling, channels start listening, literals start pumping.
 	//
-	// This method can be called multiple times, only the first has any effect (locking via sync.OnceFunc).
+	// This method can be called multiple times, only the first has any effect (via sync.Once).
 	Start()
 	// AwaitCompletion blocks until the source is closed and all UponClose hooks are complete.
 	AwaitCompletion()
@@ -27,3 +28,6 @@ type CancellableSource[T any] interface {
 	// generator is no longer polled.
 	Cancel() error
 }
+
+// Sink is a function observing the items of a [Source]. See [Source.Observe].
+type Sink[T any] func(T) error
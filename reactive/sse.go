@@ -0,0 +1,316 @@
+package reactive
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SSEEvent is one Server-Sent Event, as defined by the HTML living standard's text/event-stream format.
+type SSEEvent struct {
+	// ID is the event's id. Received events carry the most recent id sent by the server, which is replayed in the
+	// Last-Event-ID header on reconnection.
+	ID string
+	// Event is the event type. Empty means the default type, "message".
+	Event string
+	// Data is the event payload. Multi line data is sent as several data fields.
+	Data string
+	// Retry asks clients to wait this long before reconnecting. Zero leaves the client's delay unchanged.
+	Retry time.Duration
+}
+
+// DefaultSSEReconnect is how long [FromSSE] waits before reconnecting when the server has not sent a retry field.
+const DefaultSSEReconnect = 3 * time.Second
+
+// FromSSE returns a [CancellableSource] of the events streamed from a text/event-stream endpoint, requested with
+// [http.DefaultClient]. When the connection drops or fails the source reconnects after the server's retry delay, or
+// [DefaultSSEReconnect], sending the last received id as Last-Event-ID. The source completes when the context is done,
+// when it is cancelled, when the server responds 204 No Content, or when it responds with a client error other than
+// 429 Too Many Requests.
+func FromSSE(ctx context.Context, url string) CancellableSource[SSEEvent] {
+	return FromSSEWithClient(ctx, http.DefaultClient, url)
+}
+
+// FromSSEWithClient is similar to [FromSSE], but requests the stream with the provided client, which may add
+// authentication or a custom transport. The client's Timeout should be zero, as it would end every long lived stream.
+func FromSSEWithClient(ctx context.Context, client *http.Client, url string) CancellableSource[SSEEvent] {
+	ret := newSubjectSource[SSEEvent]()
+	ret.log(Verbose, "Creating SSE Source: url (%s)", url)
+	ret.setStart(func() {
+		ctx, stop := context.WithCancel(ctx)
+		defer stop()
+		go func() {
+			select {
+			case <-ret.done:
+				stop()
+			case <-ctx.Done():
+			}
+		}()
+		stream := &sseStream{reconnect: DefaultSSEReconnect}
+		for {
+			finished, err := stream.connect(ctx, client, url, func(event SSEEvent) {
+				ret.pump(event)
+			})
+			if finished && err != nil && ctx.Err() == nil {
+				ret.log(Warning, "SSE stream ended: [%v]", err)
+				return
+			}
+			if finished || ctx.Err() != nil {
+				ret.log(Debug, "SSE stream finished: [%v]", err)
+				return
+			}
+			ret.log(Warning, "SSE connection lost: [%v]. Reconnecting in %s.", err, stream.reconnect)
+			select {
+			case <-ctx.Done():
+				return
+			case <-clock.After(stream.reconnect):
+			}
+		}
+	})
+	return ret
+}
+
+// sseStream holds the state kept across reconnections.
+type sseStream struct {
+	lastID    string
+	reconnect time.Duration
+}
+
+// connect streams events from the url until the connection ends. It returns true when the server asked the client
+// not to reconnect, or when reconnecting cannot succeed.
+func (s *sseStream) connect(ctx context.Context, client *http.Client, url string, emit func(SSEEvent)) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return true, err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	request.Header.Set("Cache-Control", "no-cache")
+	if s.lastID != "" {
+		request.Header.Set("Last-Event-ID", s.lastID)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNoContent {
+		return true, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		clientError := response.StatusCode >= 400 && response.StatusCode < 500
+		retryable := !clientError || response.StatusCode == http.StatusTooManyRequests
+		return !retryable, &HTTPStatusError{StatusCode: response.StatusCode}
+	}
+	if mediaType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type")); mediaType != "text/event-stream" {
+		return true, fmt.Errorf("unexpected content type: %s", mediaType)
+	}
+	return false, s.read(bufio.NewReader(response.Body), emit)
+}
+
+// read parses events until the reader ends.
+func (s *sseStream) read(reader *bufio.Reader, emit func(SSEEvent)) error {
+	var event SSEEvent
+	var data []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		if line == "" {
+			if data != nil {
+				event.ID = s.lastID
+				event.Data = strings.Join(data, "\n")
+				emit(event)
+			}
+			event = SSEEvent{}
+			data = nil
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "":
+			// comment
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.lastID = value
+			}
+		case "retry":
+			if milliseconds, err := strconv.ParseUint(value, 10, 63); err == nil {
+				s.reconnect = time.Duration(milliseconds) * time.Millisecond
+				event.Retry = s.reconnect
+			}
+		}
+	}
+}
+
+// SSEOptions configures an [SSEHandler]. Zero values are replaced with the documented defaults.
+type SSEOptions struct {
+	// Buffer is the number of events held for each client. Events for a client whose buffer is full are dropped.
+	// Defaults to 16.
+	Buffer int
+	// Heartbeat is the interval between comment lines sent to keep idle connections open. Defaults to 15s.
+	Heartbeat time.Duration
+}
+
+type sseHandler[T any] struct {
+	encode  func(T) (SSEEvent, error)
+	options SSEOptions
+	lock    sync.Mutex
+	clients map[chan SSEEvent]struct{}
+	closed  bool
+}
+
+// SSEHandler returns an [http.Handler] serving the items of the provided [Source] to any number of clients as
+// Server-Sent Events. Each item is encoded once and sent to every connected client; clients only receive items
+// observed while they are connected. A client is unsubscribed when it disconnects. Once the Source closes, clients
+// are sent their buffered events and disconnected, and later requests are answered 204 No Content, which tells
+// browsers not to reconnect.
+//
+// SSEHandler observes the Source, so it should be called before the Source is started.
+func SSEHandler[T any](source Source[T], encode func(T) (SSEEvent, error)) http.Handler {
+	return SSEHandlerWithOptions(source, encode, SSEOptions{})
+}
+
+// SSEHandlerWithOptions is similar to [SSEHandler], but configured by the provided [SSEOptions].
+func SSEHandlerWithOptions[T any](source Source[T], encode func(T) (SSEEvent, error), options SSEOptions) http.Handler {
+	if options.Buffer <= 0 {
+		options.Buffer = 16
+	}
+	if options.Heartbeat <= 0 {
+		options.Heartbeat = 15 * time.Second
+	}
+	ret := &sseHandler[T]{
+		encode:  encode,
+		options: options,
+		clients: map[chan SSEEvent]struct{}{},
+	}
+	source.Observe(ret.broadcast)
+	source.UponClose(ret.close)
+	logger(Debug, source, "Serving source as SSE: %+v", options)
+	return ret
+}
+
+func (h *sseHandler[T]) broadcast(item T) error {
+	event, err := h.encode(item)
+	if err != nil {
+		return err
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for client := range h.clients {
+		select {
+		case client <- event:
+		default:
+			logger(Debug, h, "Client (%p) buffer full, dropping event (%.10v).", client, event)
+		}
+	}
+	return nil
+}
+
+func (h *sseHandler[T]) close() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.closed = true
+	for client := range h.clients {
+		close(client)
+	}
+	h.clients = nil
+}
+
+func (h *sseHandler[T]) subscribe() (chan SSEEvent, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.closed {
+		return nil, false
+	}
+	client := make(chan SSEEvent, h.options.Buffer)
+	h.clients[client] = struct{}{}
+	logger(Debug, h, "Client (%p) subscribed. %d clients connected.", client, len(h.clients))
+	return client, true
+}
+
+func (h *sseHandler[T]) unsubscribe(client chan SSEEvent) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, ok := h.clients[client]; ok {
+		delete(h.clients, client)
+		logger(Debug, h, "Client (%p) unsubscribed. %d clients connected.", client, len(h.clients))
+	}
+}
+
+func (h *sseHandler[T]) String() string {
+	return fmt.Sprintf("sse(%p)", h)
+}
+
+func (h *sseHandler[T]) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		http.Error(writer, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	client, ok := h.subscribe()
+	if !ok {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+	defer h.unsubscribe(client)
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	heartbeat := clock.After(h.options.Heartbeat)
+	for {
+		var err error
+		select {
+		case <-request.Context().Done():
+			return
+		case <-heartbeat:
+			heartbeat = clock.After(h.options.Heartbeat)
+			_, err = io.WriteString(writer, ": heartbeat\n\n")
+		case event, open := <-client:
+			if !open {
+				return
+			}
+			_, err = io.WriteString(writer, formatSSE(event))
+		}
+		if err != nil {
+			logger(Debug, h, "Client (%p) write failed: [%v]", client, err)
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func formatSSE(event SSEEvent) string {
+	builder := strings.Builder{}
+	if event.ID != "" {
+		fmt.Fprintf(&builder, "id: %s\n", event.ID)
+	}
+	if event.Event != "" {
+		fmt.Fprintf(&builder, "event: %s\n", event.Event)
+	}
+	if event.Retry > 0 {
+		fmt.Fprintf(&builder, "retry: %d\n", event.Retry.Milliseconds())
+	}
+	for _, line := range strings.Split(event.Data, "\n") {
+		fmt.Fprintf(&builder, "data: %s\n", line)
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
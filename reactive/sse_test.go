@@ -0,0 +1,215 @@
+package reactive
+
+import (
+	"bufio"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func collectSSE(source Source[SSEEvent]) func() []SSEEvent {
+	lock := sync.Mutex{}
+	var results []SSEEvent
+	source.Observe(func(event SSEEvent) error {
+		lock.Lock()
+		defer lock.Unlock()
+		results = append(results, event)
+		return nil
+	})
+	return func() []SSEEvent {
+		lock.Lock()
+		defer lock.Unlock()
+		return results
+	}
+}
+
+func TestFromSSE_ReconnectsWithLastEventID(t *testing.T) {
+	var lastEventIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		lastEventIDs = append(lastEventIDs, request.Header.Get("Last-Event-ID"))
+		switch len(lastEventIDs) {
+		case 1:
+			writer.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(writer, "retry: 10\nid: 1\ndata: a\n\n: comment\r\nevent: update\ndata: b\ndata:c\n\n")
+		case 2:
+			writer.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+			_, _ = io.WriteString(writer, "id: 2\ndata: d\n\nid: 3\n")
+		default:
+			writer.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	underTest := FromSSE(context.Background(), server.URL)
+	results := collectSSE(underTest)
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []SSEEvent{
+		{ID: "1", Data: "a", Retry: 10 * time.Millisecond},
+		{ID: "1", Event: "update", Data: "b\nc"},
+		{ID: "2", Data: "d"},
+	}, results())
+	assert.Equal(t, []string{"", "1", "3"}, lastEventIDs)
+}
+
+func TestFromSSE_CompletesWhenContextDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/event-stream")
+		writer.WriteHeader(http.StatusOK)
+		writer.(http.Flusher).Flush()
+		<-request.Context().Done()
+	}))
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	underTest := FromSSE(ctx, server.URL)
+	underTest.Start()
+	cancel()
+	underTest.AwaitCompletion()
+}
+
+func TestFromSSE_CompletesOnClientErrors(t *testing.T) {
+	var statuses []int
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		status := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusNotFound}[len(statuses)]
+		statuses = append(statuses, status)
+		writer.Header().Set("Content-Type", "text/event-stream")
+		writer.WriteHeader(status)
+	}))
+	defer server.Close()
+	fake := useFakeClock(t)
+	underTest := FromSSE(context.Background(), server.URL)
+	underTest.Start()
+	fake.AwaitWaiters(1)
+	fake.Advance(DefaultSSEReconnect)
+	fake.AwaitWaiters(1)
+	fake.Advance(DefaultSSEReconnect)
+	underTest.AwaitCompletion()
+	assert.Equal(t, []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusNotFound}, statuses)
+}
+
+func TestFromSSEWithClient_UsesClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("Authorization") != "Bearer token" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writer.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(writer, "data: a\n\n")
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: authorizing{server.Client().Transport}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	underTest := FromSSEWithClient(ctx, client, server.URL)
+	received := make(chan SSEEvent, 1)
+	underTest.Observe(func(event SSEEvent) error {
+		received <- event
+		cancel()
+		return nil
+	})
+	underTest.Start()
+	assert.Equal(t, "a", (<-received).Data)
+	underTest.AwaitCompletion()
+}
+
+type authorizing struct {
+	http.RoundTripper
+}
+
+func (a authorizing) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.Header.Set("Authorization", "Bearer token")
+	return a.RoundTripper.RoundTrip(request)
+}
+
+func awaitClients[T any](t *testing.T, handler http.Handler, count int) {
+	underTest := handler.(*sseHandler[T])
+	assert.Eventually(t, func() bool {
+		underTest.lock.Lock()
+		defer underTest.lock.Unlock()
+		return len(underTest.clients) == count
+	}, time.Second, time.Millisecond)
+}
+
+func TestSSEHandler_ServesFromSSE(t *testing.T) {
+	source := newSubjectSource[string]()
+	handler := SSEHandler[string](source, func(item string) (SSEEvent, error) {
+		return SSEEvent{ID: item, Data: "item " + item, Retry: 10 * time.Millisecond}, nil
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	source.Start()
+	var clients []CancellableSource[SSEEvent]
+	var results []func() []SSEEvent
+	for range 2 {
+		client := FromSSE(context.Background(), server.URL)
+		results = append(results, collectSSE(client))
+		client.Start()
+		clients = append(clients, client)
+	}
+	awaitClients[string](t, handler, 2)
+	source.pump("1")
+	source.pump("2")
+	require.NoError(t, source.Cancel())
+	source.AwaitCompletion()
+	expected := []SSEEvent{
+		{ID: "1", Data: "item 1", Retry: 10 * time.Millisecond},
+		{ID: "2", Data: "item 2", Retry: 10 * time.Millisecond},
+	}
+	for index, client := range clients {
+		client.AwaitCompletion()
+		assert.Equal(t, expected, results[index]())
+	}
+}
+
+func TestSSEHandler_SendsHeartbeatsAndUnsubscribes(t *testing.T) {
+	source := newSubjectSource[string]()
+	handler := SSEHandlerWithOptions[string](source, func(item string) (SSEEvent, error) {
+		return SSEEvent{Data: item}, nil
+	}, SSEOptions{Heartbeat: 10 * time.Millisecond})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+	line, err := bufio.NewReader(response.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(line, ":"), line)
+	awaitClients[string](t, handler, 1)
+	cancel()
+	awaitClients[string](t, handler, 0)
+}
+
+func TestSSEHandler_RefusesAfterClose(t *testing.T) {
+	source := Just("foobar")
+	handler := SSEHandler(source, func(item string) (SSEEvent, error) {
+		return SSEEvent{Data: item}, nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	response, err := http.Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+}
+
+func TestFormatSSE(t *testing.T) {
+	assert.Equal(t,
+		"id: 7\nevent: update\nretry: 1500\ndata: a\ndata: b\n\n",
+		formatSSE(SSEEvent{ID: "7", Event: "update", Data: "a\nb", Retry: 1500 * time.Millisecond}),
+	)
+	assert.Equal(t, "data: \n\n", formatSSE(SSEEvent{}))
+}
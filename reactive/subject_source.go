@@ -0,0 +1,41 @@
+package reactive
+
+import (
+	"sync"
+)
+
+// subjectSource is a [CancellableSource] that runs until cancelled. By default its items are pumped directly by its
+// owner; constructors may instead replace the start function with one that pumps items and watches done.
+type subjectSource[T any] struct {
+	baseSource[T]
+	done   chan struct{}
+	cancel func()
+}
+
+func newSubjectSource[T any]() *subjectSource[T] {
+	ret := subjectSource[T]{
+		done: make(chan struct{}),
+	}
+	ret.cancel = sync.OnceFunc(func() {
+		close(ret.done)
+	})
+	ret.setStart(func() {
+		<-ret.done
+	})
+	return &ret
+}
+
+func (s *subjectSource[T]) Cancel() error {
+	s.log(Info, "Cancel request received. Marking source as closed.")
+	s.cancel()
+	return nil
+}
+
+func (s *subjectSource[T]) cancelled() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
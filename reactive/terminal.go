@@ -0,0 +1,106 @@
+package reactive
+
+import (
+	"context"
+	"errors"
+)
+
+// NoItemsError is returned by [First] and [Last] when the Source completes without producing an item.
+type NoItemsError struct{}
+
+// Error implements the error interface
+func (n *NoItemsError) Error() string {
+	return "Source completed without items"
+}
+
+// errStopIteration stops ForEach early without reporting an error.
+var errStopIteration = errors.New("stop iteration")
+
+// ToChan observes and starts the provided [Source], and returns a channel of its items with the provided buffer
+// size. The channel is closed once the Source closes. The Source waits for room in the channel, so the channel
+// should be drained.
+func ToChan[T any](source Source[T], buffer int) <-chan T {
+	c := make(chan T, buffer)
+	source.Observe(func(item T) error {
+		c <- item
+		return nil
+	})
+	source.UponClose(func() {
+		logger(Debug, source, "Closing output chan (%p).", c)
+		close(c)
+	})
+	source.Start()
+	return c
+}
+
+// ForEach observes and starts the provided [Source], calls fn with each item in turn and waits for the Source to
+// complete. If fn returns an error, or the context is done, iteration stops early and the error is returned; the
+// Source is cancelled if it is a [CancellableSource].
+func ForEach[T any](ctx context.Context, source Source[T], fn func(T) error) error {
+	for item, err := range ToSeqErr(ctx, source) {
+		if err == nil {
+			err = fn(item)
+		}
+		if errors.Is(err, errStopIteration) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	source.AwaitCompletion()
+	return nil
+}
+
+// Collect observes and starts the provided [Source], and returns all of its items once it completes. If the context
+// is done first, the items collected so far are returned with the context's error.
+func Collect[T any](ctx context.Context, source Source[T]) ([]T, error) {
+	var ret []T
+	err := ForEach(ctx, source, func(item T) error {
+		ret = append(ret, item)
+		return nil
+	})
+	return ret, err
+}
+
+// First observes and starts the provided [Source], and returns its first item. The Source is cancelled after the
+// first item if it is a [CancellableSource]. A [NoItemsError] is returned if the Source completes without items.
+func First[T any](ctx context.Context, source Source[T]) (T, error) {
+	var ret T
+	found := false
+	err := ForEach(ctx, source, func(item T) error {
+		ret = item
+		found = true
+		return errStopIteration
+	})
+	if err == nil && !found {
+		err = &NoItemsError{}
+	}
+	return ret, err
+}
+
+// Last observes and starts the provided [Source], and returns its last item once it completes. A [NoItemsError] is
+// returned if the Source completes without items.
+func Last[T any](ctx context.Context, source Source[T]) (T, error) {
+	var ret T
+	found := false
+	err := ForEach(ctx, source, func(item T) error {
+		ret = item
+		found = true
+		return nil
+	})
+	if err == nil && !found {
+		err = &NoItemsError{}
+	}
+	return ret, err
+}
+
+// Count observes and starts the provided [Source], and returns the number of items it produced once it completes.
+func Count[T any](ctx context.Context, source Source[T]) (int, error) {
+	count := 0
+	err := ForEach(ctx, source, func(T) error {
+		count++
+		return nil
+	})
+	return count, err
+}
@@ -0,0 +1,100 @@
+package reactive
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestToChan_HappyPath(t *testing.T) {
+	var results []string
+	for item := range ToChan(Just("foobar", "test"), 1) {
+		results = append(results, item)
+	}
+	assert.Equal(t, []string{"foobar", "test"}, results)
+}
+
+func TestCollect_HappyPath(t *testing.T) {
+	results, err := Collect(context.Background(), Just(1, 2, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, results)
+}
+
+func TestCollect_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	source := FromGenerator(func() (*int, error) {
+		return nil, nil
+	})
+	results, err := Collect[int](ctx, source)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, results)
+	source.AwaitCompletion()
+}
+
+func TestToChan_AlreadyStartedSource(t *testing.T) {
+	source := Just(1, 2, 3)
+	mapped := Map(source, func(item int) (int, error) {
+		return item * 2, nil
+	})
+	results := ToChan(mapped, 3)
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, []int{2, 4, 6}, []int{<-results, <-results, <-results})
+}
+
+func TestCollect_CompletedSource(t *testing.T) {
+	source := Just(1, 2, 3)
+	source.Start()
+	source.AwaitCompletion()
+	results, err := Collect(context.Background(), source)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestForEach_StopsOnError(t *testing.T) {
+	expected := errors.New("test error")
+	source := Range(0, 100)
+	count := 0
+	err := ForEach[int](context.Background(), source, func(item int) error {
+		count++
+		if item == 2 {
+			return expected
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, expected)
+	assert.Equal(t, 3, count)
+	source.AwaitCompletion()
+}
+
+func TestFirst_HappyPath(t *testing.T) {
+	source := Interval(0)
+	first, err := First[int64](context.Background(), source)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), first)
+	source.AwaitCompletion()
+}
+
+func TestFirst_Empty(t *testing.T) {
+	_, err := First(context.Background(), FromSlice([]string{}))
+	var noItems *NoItemsError
+	assert.ErrorAs(t, err, &noItems)
+	assert.NotEmpty(t, err.Error())
+}
+
+func TestLast_HappyPath(t *testing.T) {
+	last, err := Last(context.Background(), Just("foobar", "test"))
+	assert.NoError(t, err)
+	assert.Equal(t, "test", last)
+	_, err = Last(context.Background(), FromSlice([]string{}))
+	assert.Error(t, err)
+}
+
+func TestCount_HappyPath(t *testing.T) {
+	count, err := Count[int](context.Background(), Range(0, 42))
+	assert.NoError(t, err)
+	assert.Equal(t, 42, count)
+}
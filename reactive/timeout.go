@@ -0,0 +1,112 @@
+package reactive
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TimeoutError is returned when a sink does not complete, or a Source does not produce an item, within a deadline.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+// Error implements the error interface
+func (t *TimeoutError) Error() string {
+	return fmt.Sprintf("Timed out after %s", t.Timeout)
+}
+
+// WithTimeout returns a [Sink] that fails with [TimeoutError] if the provided sink does not complete within the
+// timeout. The provided sink keeps running in the background, but the Source it observes moves on to the next item.
+// At most one call of the provided sink runs at a time: a call made while an earlier call is still running waits for
+// it, and the wait counts against the timeout.
+func WithTimeout[T any](sink func(T) error, timeout time.Duration) Sink[T] {
+	running := make(chan struct{}, 1)
+	return func(item T) error {
+		deadline := clock.After(timeout)
+		select {
+		case running <- struct{}{}:
+		case <-deadline:
+			return &TimeoutError{Timeout: timeout}
+		}
+		result := make(chan error, 1)
+		go func() {
+			defer func() {
+				<-running
+			}()
+			defer func() {
+				if err := recover(); err != nil {
+					result <- fmt.Errorf("panic: %v", err)
+				}
+			}()
+			result <- sink(item)
+		}()
+		select {
+		case err := <-result:
+			return err
+		case <-deadline:
+			return &TimeoutError{Timeout: timeout}
+		}
+	}
+}
+
+// Timeout observes one [Source] and returns a [Source] of the same items. If no item is observed within the timeout,
+// measured from creation and then from each item, the returned Source closes and, if the observed Source is a
+// [CancellableSource], the observed Source is cancelled. Items observed after the timeout are dropped without
+// reaching the observed Source's error path.
+//
+// The first timeout runs from creation, so create the Timeout right before starting the observed Source:
+//
+//	timed := Timeout(source, time.Minute)
+//	timed.Observe(sink)
+//	source.Start()
+//
+// The returned Source is already started.
+func Timeout[T any](source Source[T], timeout time.Duration) Source[T] {
+	c := make(chan T)
+	ret := fromChan(c)
+	lock := sync.Mutex{}
+	closed := false
+	closeChan := func() {
+		if !closed {
+			closed = true
+			ret.log(Debug, "Closing timeout chan (%p).", c)
+			close(c)
+		}
+	}
+	var timer Stopper
+	var expire func()
+	expire = func() {
+		lock.Lock()
+		defer lock.Unlock()
+		if closed {
+			return
+		}
+		ret.log(Warning, "No item observed within %s. Closing source.", timeout)
+		closeChan()
+		cancelIfPossible(source)
+	}
+	timer = clock.AfterFunc(timeout, expire)
+	source.UponClose(func() {
+		lock.Lock()
+		timer.Stop()
+		closeChan()
+		lock.Unlock()
+		ret.AwaitCompletion()
+	})
+	source.Observe(func(item T) error {
+		lock.Lock()
+		defer lock.Unlock()
+		if closed {
+			ret.log(Debug, "Dropping item (%.10v) observed after the timeout.", item)
+			return nil
+		}
+		timer.Stop()
+		c <- item
+		timer = clock.AfterFunc(timeout, expire)
+		return nil
+	})
+	ret.log(Debug, "Created timeout source. timeout (%s).", timeout)
+	ret.Start()
+	return ret
+}
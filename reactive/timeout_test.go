@@ -0,0 +1,140 @@
+package reactive
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout_FailsSlowSinks(t *testing.T) {
+	fake := useFakeClock(t)
+	release := make(chan struct{})
+	underTest := WithTimeout(func(item string) error {
+		<-release
+		return nil
+	}, time.Second)
+	done := make(chan error)
+	go func() {
+		done <- underTest("test")
+	}()
+	fake.AwaitWaiters(1)
+	fake.Advance(time.Second)
+	err := <-done
+	var timeoutError *TimeoutError
+	assert.ErrorAs(t, err, &timeoutError)
+	assert.Equal(t, time.Second, timeoutError.Timeout)
+	assert.NotEmpty(t, err.Error())
+	close(release)
+}
+
+func TestWithTimeout_PassesResults(t *testing.T) {
+	expected := errors.New("test error")
+	assert.ErrorIs(t, WithTimeout(func(string) error { return expected }, time.Hour)("test"), expected)
+	assert.ErrorContains(t, WithTimeout(func(string) error { panic("test panic") }, time.Hour)("test"), "test panic")
+}
+
+func TestWithTimeout_SkipsHungSink(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	underTest := Just("foobar", "test")
+	var results []string
+	underTest.Observe(WithTimeout(func(item string) error {
+		<-release
+		return nil
+	}, 10*time.Millisecond))
+	underTest.Observe(func(item string) error {
+		results = append(results, item)
+		return nil
+	})
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"foobar", "test"}, results)
+}
+
+func TestWithTimeout_DoesNotCallBusySink(t *testing.T) {
+	fake := useFakeClock(t)
+	release := make(chan struct{})
+	calls := atomic.Int32{}
+	underTest := WithTimeout(func(item string) error {
+		calls.Add(1)
+		<-release
+		return nil
+	}, time.Second)
+	done := make(chan error)
+	go func() {
+		done <- underTest("foobar")
+	}()
+	fake.AwaitWaiters(1)
+	fake.Advance(time.Second)
+	assert.Error(t, <-done)
+	go func() {
+		done <- underTest("test")
+	}()
+	fake.AwaitWaiters(1)
+	fake.Advance(time.Second)
+	var timeoutError *TimeoutError
+	assert.ErrorAs(t, <-done, &timeoutError)
+	assert.Equal(t, int32(1), calls.Load())
+	close(release)
+	assert.NoError(t, underTest("fizzbuzz"))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestTimeout_ClosesAndCancelsIdleSource(t *testing.T) {
+	fake := useFakeClock(t)
+	source := FromGenerator(func() (*int, error) {
+		return nil, nil
+	})
+	timed := Timeout[int](source, time.Second)
+	closed := make(chan struct{})
+	timed.UponClose(func() {
+		close(closed)
+	})
+	source.Start()
+	fake.Advance(time.Second)
+	<-closed
+	source.AwaitCompletion()
+}
+
+func TestTimeout_ForwardsItems(t *testing.T) {
+	useFakeClock(t)
+	source := Just(1, 2, 3)
+	timed := Timeout(source, time.Second)
+	var results []int
+	timed.Observe(func(item int) error {
+		results = append(results, item)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, []int{1, 2, 3}, results)
+}
+
+func TestTimeout_DropsLateItems(t *testing.T) {
+	fake := useFakeClock(t)
+	logs := captureLogs(t)
+	c := make(chan int)
+	source := FromChan(c)
+	timed := Timeout(source, time.Second)
+	results := make(chan int, 10)
+	timed.Observe(func(item int) error {
+		results <- item
+		return nil
+	})
+	source.Start()
+	c <- 1
+	assert.Equal(t, 1, <-results)
+	fake.AwaitWaiters(1)
+	fake.Advance(time.Second)
+	timed.AwaitCompletion()
+	c <- 2
+	close(c)
+	source.AwaitCompletion()
+	assert.Empty(t, results)
+	for _, message := range logs() {
+		assert.NotContains(t, message, "Failed to write item")
+	}
+	checkForLog(t, logs(), Debug, "observed after the timeout")
+}
@@ -0,0 +1,266 @@
+package reactive
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Window is a group of items observed in the half open span of time [Start, End).
+type Window[T any] struct {
+	Start time.Time
+	End   time.Time
+	Items []T
+}
+
+// windower assigns timestamped items to windows and emits windows once a watermark passes their end.
+// A windower is not safe for concurrent use.
+type windower[T any] struct {
+	// assign returns the windows (without items) an item with the provided timestamp belongs to.
+	assign func(timestamp time.Time) []Window[T]
+	// merging windows are combined with any open window they overlap (session windows).
+	merging bool
+	// open windows, ordered by End.
+	open []*Window[T]
+	emit func(Window[T])
+}
+
+// checkPositive panics unless the named duration argument of the operator is positive.
+func checkPositive(operator string, name string, d time.Duration) {
+	if d <= 0 {
+		panic(fmt.Sprintf("reactive: %s %s must be positive, found %s", operator, name, d))
+	}
+}
+
+func slidingAssigner[T any](size time.Duration, slide time.Duration) func(time.Time) []Window[T] {
+	return func(timestamp time.Time) []Window[T] {
+		var ret []Window[T]
+		for start := timestamp.Truncate(slide); start.Add(size).After(timestamp); start = start.Add(-slide) {
+			ret = append(ret, Window[T]{Start: start, End: start.Add(size)})
+		}
+		return ret
+	}
+}
+
+func sessionAssigner[T any](gap time.Duration) func(time.Time) []Window[T] {
+	return func(timestamp time.Time) []Window[T] {
+		return []Window[T]{{Start: timestamp, End: timestamp.Add(gap)}}
+	}
+}
+
+// placement describes where [windower.add] put an item.
+type placement int
+
+const (
+	// addedToWindow items were added to at least one window.
+	addedToWindow placement = iota
+	// betweenWindows items belong to no window, because they fall in a gap between sliding windows.
+	betweenWindows
+	// behindWatermark items only belong to windows that have already been emitted.
+	behindWatermark
+)
+
+// add places the item into every window it belongs to that has not already been emitted, i.e. whose end is after
+// the watermark.
+func (w *windower[T]) add(timestamp time.Time, item T, watermark time.Time) placement {
+	candidates := w.assign(timestamp)
+	if len(candidates) == 0 {
+		return betweenWindows
+	}
+	result := behindWatermark
+	for _, candidate := range candidates {
+		if !candidate.End.After(watermark) {
+			continue
+		}
+		result = addedToWindow
+		if w.merging {
+			w.merge(candidate, item)
+			continue
+		}
+		window := w.find(candidate.Start, candidate.End)
+		if window == nil {
+			window = &Window[T]{Start: candidate.Start, End: candidate.End}
+			w.insert(window)
+		}
+		window.Items = append(window.Items, item)
+	}
+	return result
+}
+
+func (w *windower[T]) find(start time.Time, end time.Time) *Window[T] {
+	for _, window := range w.open {
+		if window.Start.Equal(start) && window.End.Equal(end) {
+			return window
+		}
+	}
+	return nil
+}
+
+func (w *windower[T]) insert(window *Window[T]) {
+	index, _ := slices.BinarySearchFunc(w.open, window, func(a *Window[T], b *Window[T]) int {
+		return a.End.Compare(b.End)
+	})
+	w.open = slices.Insert(w.open, index, window)
+}
+
+func (w *windower[T]) merge(candidate Window[T], item T) {
+	merged := &Window[T]{Start: candidate.Start, End: candidate.End}
+	var overlapping []*Window[T]
+	w.open = slices.DeleteFunc(w.open, func(window *Window[T]) bool {
+		if window.Start.Before(merged.End) && merged.Start.Before(window.End) {
+			overlapping = append(overlapping, window)
+			return true
+		}
+		return false
+	})
+	slices.SortFunc(overlapping, func(a *Window[T], b *Window[T]) int {
+		return a.Start.Compare(b.Start)
+	})
+	for _, window := range overlapping {
+		if window.Start.Before(merged.Start) {
+			merged.Start = window.Start
+		}
+		if window.End.After(merged.End) {
+			merged.End = window.End
+		}
+		merged.Items = append(merged.Items, window.Items...)
+	}
+	merged.Items = append(merged.Items, item)
+	w.insert(merged)
+}
+
+// fire emits, in order of their end, every open window whose end is not after the watermark.
+func (w *windower[T]) fire(watermark time.Time) {
+	for len(w.open) > 0 && !w.open[0].End.After(watermark) {
+		window := w.open[0]
+		w.open = w.open[1:]
+		w.emit(*window)
+	}
+}
+
+// flush emits every open window.
+func (w *windower[T]) flush() {
+	for _, window := range w.open {
+		w.emit(*window)
+	}
+	w.open = nil
+}
+
+func (w *windower[T]) nextEnd() (time.Time, bool) {
+	if len(w.open) == 0 {
+		return time.Time{}, false
+	}
+	return w.open[0].End, true
+}
+
+// TumblingWindow observes one [Source] and returns a [Source] of consecutive, non overlapping [Window] values of the
+// provided size. Items are assigned to windows by their arrival time. Windows are aligned to multiples of size and
+// are emitted once their end has passed. Windows without items are not emitted.
+//
+// When the observed Source closes, any open windows are emitted before the returned Source closes. TumblingWindow
+// panics if size is not positive.
+//
+// The returned Source is already started.
+func TumblingWindow[T any](source Source[T], size time.Duration) Source[Window[T]] {
+	checkPositive("TumblingWindow", "size", size)
+	return processingTimeWindow(source, &windower[T]{assign: slidingAssigner[T](size, size)})
+}
+
+// SlidingWindow observes one [Source] and returns a [Source] of overlapping [Window] values. A window of the provided
+// size starts every slide, so each item is observed in size/slide windows. Windows without items are not emitted.
+// When slide is larger than size the windows leave gaps; items arriving in a gap belong to no window and are dropped.
+//
+// When the observed Source closes, any open windows are emitted before the returned Source closes. SlidingWindow
+// panics if size or slide is not positive.
+//
+// The returned Source is already started.
+func SlidingWindow[T any](source Source[T], size time.Duration, slide time.Duration) Source[Window[T]] {
+	checkPositive("SlidingWindow", "size", size)
+	checkPositive("SlidingWindow", "slide", slide)
+	return processingTimeWindow(source, &windower[T]{assign: slidingAssigner[T](size, slide)})
+}
+
+// SessionWindow observes one [Source] and returns a [Source] of [Window] values grouping bursts of activity. A window
+// is emitted once no item has arrived for the provided gap. The window ends gap after its last item.
+//
+// When the observed Source closes, any open window is emitted before the returned Source closes. SessionWindow panics
+// if gap is not positive.
+//
+// The returned Source is already started.
+func SessionWindow[T any](source Source[T], gap time.Duration) Source[Window[T]] {
+	checkPositive("SessionWindow", "gap", gap)
+	return processingTimeWindow(source, &windower[T]{assign: sessionAssigner[T](gap), merging: true})
+}
+
+func processingTimeWindow[T any](source Source[T], w *windower[T]) Source[Window[T]] {
+	c := make(chan Window[T])
+	ret := fromChan(c)
+	lock := sync.Mutex{}
+	var timer Stopper
+	closed := false
+	w.emit = func(window Window[T]) {
+		ret.log(Verbose, "Emitting window [%s, %s) with %d items.", window.Start, window.End, len(window.Items))
+		c <- window
+	}
+	var schedule func()
+	schedule = func() {
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+		end, ok := w.nextEnd()
+		if !ok {
+			return
+		}
+		timer = clock.AfterFunc(end.Sub(clock.Now()), func() {
+			lock.Lock()
+			defer lock.Unlock()
+			if closed {
+				return
+			}
+			w.fire(clock.Now())
+			schedule()
+		})
+	}
+	source.UponClose(func() {
+		lock.Lock()
+		if timer != nil {
+			timer.Stop()
+		}
+		w.flush()
+		closed = true
+		ret.log(Debug, "Closing windowing chan (%p).", c)
+		close(c)
+		lock.Unlock()
+		ret.AwaitCompletion()
+	})
+	source.Observe(func(item T) error {
+		lock.Lock()
+		defer lock.Unlock()
+		now := clock.Now()
+		w.fire(now)
+		if w.add(now, item, now) != addedToWindow {
+			ret.log(Debug, "Item (%.10v) falls between windows. Dropping it.", item)
+		}
+		schedule()
+		return nil
+	})
+	ret.log(Debug, "Created time windowing source.")
+	ret.Start()
+	return ret
+}
+
+// Aggregate observes a [Source] of [Window] values and returns a [Source] of the result of the provided aggregator
+// applied to each window. For example, counting items per minute:
+//
+//	Aggregate(TumblingWindow(source, time.Minute), func(window Window[string]) int {
+//	  return len(window.Items)
+//	})
+//
+// The returned Source is already started.
+func Aggregate[T any, A any](source Source[Window[T]], aggregator func(Window[T]) A) Source[A] {
+	return Map(source, func(window Window[T]) (A, error) {
+		return aggregator(window), nil
+	})
+}
@@ -0,0 +1,140 @@
+package reactive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTumblingWindow_GroupsByArrival(t *testing.T) {
+	fake := useFakeClock(t)
+	source, paused, resume := pausingSource([]int{1, 2}, 3)
+	windowed := TumblingWindow(source, 50*time.Millisecond)
+	results := make(chan Window[int], 10)
+	windowed.Observe(func(window Window[int]) error {
+		results <- window
+		return nil
+	})
+	source.Start()
+	<-paused
+	fake.Advance(50 * time.Millisecond)
+	first := <-results
+	resume()
+	source.AwaitCompletion()
+	second := <-results
+	assert.Equal(t, []int{1, 2}, first.Items)
+	assert.Equal(t, []int{3}, second.Items)
+	assert.Equal(t, fake.Now().Add(-50*time.Millisecond), first.Start)
+	assert.Equal(t, 50*time.Millisecond, first.End.Sub(first.Start))
+	assert.Equal(t, first.End, second.Start)
+}
+
+func TestSessionWindow_ClosesAfterGap(t *testing.T) {
+	fake := useFakeClock(t)
+	source, paused, resume := pausingSource([]int{1, 2}, 3)
+	windowed := SessionWindow(source, 20*time.Millisecond)
+	emitted := make(chan Window[int], 10)
+	windowed.Observe(func(window Window[int]) error {
+		emitted <- window
+		return nil
+	})
+	source.Start()
+	<-paused
+	fake.Advance(19 * time.Millisecond)
+	assert.Empty(t, emitted)
+	fake.Advance(time.Millisecond)
+	assert.Equal(t, []int{1, 2}, (<-emitted).Items)
+	resume()
+	source.AwaitCompletion()
+	assert.Equal(t, []int{3}, (<-emitted).Items)
+}
+
+func TestSlidingWindow_FlushesOnClose(t *testing.T) {
+	source := Just(1, 2)
+	windowed := SlidingWindow(source, time.Hour, 30*time.Minute)
+	var results []Window[int]
+	windowed.Observe(func(window Window[int]) error {
+		results = append(results, window)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Len(t, results, 2)
+	for _, window := range results {
+		assert.Equal(t, []int{1, 2}, window.Items)
+	}
+}
+
+func TestWindower_SlidingAssignment(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var results []Window[string]
+	underTest := windower[string]{
+		assign: slidingAssigner[string](10*time.Second, 5*time.Second),
+		emit: func(window Window[string]) {
+			results = append(results, window)
+		},
+	}
+	underTest.add(base.Add(7*time.Second), "a", base)
+	underTest.add(base.Add(12*time.Second), "b", base)
+	underTest.fire(base.Add(15 * time.Second))
+	assert.Equal(t, []Window[string]{
+		{Start: base, End: base.Add(10 * time.Second), Items: []string{"a"}},
+		{Start: base.Add(5 * time.Second), End: base.Add(15 * time.Second), Items: []string{"a", "b"}},
+	}, results)
+	underTest.flush()
+	assert.Equal(t, Window[string]{
+		Start: base.Add(10 * time.Second),
+		End:   base.Add(20 * time.Second),
+		Items: []string{"b"},
+	}, results[2])
+}
+
+func TestWindower_SessionsMerge(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var results []Window[string]
+	underTest := windower[string]{
+		assign:  sessionAssigner[string](time.Second),
+		merging: true,
+		emit: func(window Window[string]) {
+			results = append(results, window)
+		},
+	}
+	underTest.add(base, "a", base)
+	underTest.add(base.Add(1500*time.Millisecond), "c", base)
+	underTest.add(base.Add(800*time.Millisecond), "b", base)
+	underTest.add(base.Add(5*time.Second), "d", base)
+	underTest.fire(base.Add(3 * time.Second))
+	assert.Equal(t, []Window[string]{{
+		Start: base,
+		End:   base.Add(2500 * time.Millisecond),
+		Items: []string{"a", "c", "b"},
+	}}, results)
+}
+
+func TestAggregate_CountsItems(t *testing.T) {
+	source := Just(1, 2, 3)
+	counts := Aggregate(TumblingWindow(source, time.Hour), func(window Window[int]) int {
+		return len(window.Items)
+	})
+	var results []int
+	counts.Observe(func(count int) error {
+		results = append(results, count)
+		return nil
+	})
+	source.Start()
+	source.AwaitCompletion()
+	assert.Equal(t, []int{3}, results)
+}
+
+func TestWindows_RejectNonPositiveDurations(t *testing.T) {
+	assert.Panics(t, func() { TumblingWindow(Just(1), 0) })
+	assert.Panics(t, func() { SlidingWindow(Just(1), time.Second, 0) })
+	assert.Panics(t, func() { SlidingWindow(Just(1), -time.Second, time.Second) })
+	assert.Panics(t, func() { SessionWindow(Just(1), 0) })
+}
+
+func TestWindower_SlidingAssignmentWithGaps(t *testing.T) {
+	assign := slidingAssigner[int](time.Second, 3*time.Second)
+	assert.Len(t, assign(eventBase.Add(500*time.Millisecond)), 1)
+	assert.Empty(t, assign(eventBase.Add(1500*time.Millisecond)))
+}
@@ -0,0 +1,126 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/AndreasChristianson/gopher-pipes/reactive"
+	"github.com/redis/go-redis/v9"
+)
+
+// Subscription names a Pub/Sub channel, or a glob style pattern of channels, for [FromPubSub].
+type Subscription struct {
+	Name    string
+	Pattern bool
+}
+
+// Channel returns a [Subscription] to the named channel (SUBSCRIBE).
+func Channel(name string) Subscription {
+	return Subscription{Name: name}
+}
+
+// Pattern returns a [Subscription] to every channel matching the glob style pattern (PSUBSCRIBE).
+func Pattern(pattern string) Subscription {
+	return Subscription{Name: pattern, Pattern: true}
+}
+
+// Payload returns the message's payload. It may be passed to [FromPubSub] as the decode function.
+func Payload(message *redis.Message) (string, error) {
+	return message.Payload, nil
+}
+
+type pubSubSource[T any] struct {
+	reactive.CancellableSource[T]
+	lock   sync.Mutex
+	pubsub *redis.PubSub
+	closed bool
+}
+
+// FromPubSub returns a [reactive.CancellableSource] of the messages published to the provided subscriptions, decoded
+// with the provided function. A message that fails to decode is reported through the generator error path and
+// skipped. The subscriptions are made when the source starts; Pub/Sub does not store messages, so messages published
+// before then are never observed.
+//
+// Dropped connections are re-established, and the subscriptions renewed, by go-redis; messages published while
+// disconnected are lost. The subscription is closed when the source is cancelled or closes.
+func FromPubSub[T any](
+	client redis.UniversalClient,
+	decode func(*redis.Message) (T, error),
+	subscriptions ...Subscription,
+) reactive.CancellableSource[T] {
+	ret := &pubSubSource[T]{}
+	var messages <-chan *redis.Message
+	ret.CancellableSource = reactive.FromGeneratorWithBackoff(func() (*T, error) {
+		if messages == nil {
+			var err error
+			if messages, err = ret.subscribe(client, subscriptions); err != nil {
+				// go-redis resubscribes when it reconnects, so a failed subscription is only reported.
+				return nil, err
+			}
+		}
+		message, open := <-messages
+		if !open {
+			return nil, &reactive.GeneratorFinished{}
+		}
+		item, err := decode(message)
+		if err != nil {
+			return nil, err
+		}
+		return &item, nil
+	}, nil)
+	ret.UponClose(func() {
+		_ = ret.close()
+	})
+	return ret
+}
+
+// subscribe makes the subscriptions, unless the source was already cancelled. The returned channel is nil only in
+// that case.
+func (p *pubSubSource[T]) subscribe(client redis.UniversalClient, subscriptions []Subscription) (
+	<-chan *redis.Message,
+	error,
+) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed {
+		return nil, &reactive.GeneratorFinished{}
+	}
+	var channels, patterns []string
+	for _, subscription := range subscriptions {
+		if subscription.Pattern {
+			patterns = append(patterns, subscription.Name)
+		} else {
+			channels = append(channels, subscription.Name)
+		}
+	}
+	ctx := context.Background()
+	p.pubsub = client.Subscribe(ctx)
+	var err error
+	if len(channels) > 0 {
+		err = p.pubsub.Subscribe(ctx, channels...)
+	}
+	if len(patterns) > 0 {
+		err = errors.Join(err, p.pubsub.PSubscribe(ctx, patterns...))
+	}
+	return p.pubsub.Channel(), err
+}
+
+// close closes the subscription, if one was made. Later subscriptions are refused.
+func (p *pubSubSource[T]) close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	if p.pubsub == nil {
+		return nil
+	}
+	return p.pubsub.Close()
+}
+
+// Cancel stops the source and closes the subscription.
+func (p *pubSubSource[T]) Cancel() error {
+	return errors.Join(p.CancellableSource.Cancel(), p.close())
+}
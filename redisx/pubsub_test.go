@@ -0,0 +1,121 @@
+package redisx
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collect[T any](source interface{ Observe(func(T) error) }) func() []T {
+	lock := sync.Mutex{}
+	var results []T
+	source.Observe(func(item T) error {
+		lock.Lock()
+		defer lock.Unlock()
+		results = append(results, item)
+		return nil
+	})
+	return func() []T {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]T(nil), results...)
+	}
+}
+
+func awaitSubscribers(t *testing.T, server *miniredis.Miniredis, count int) {
+	assert.Eventually(t, func() bool {
+		return server.PubSubNumPat()+len(server.PubSubChannels("")) >= count
+	}, time.Second, time.Millisecond)
+}
+
+func TestFromPubSub_ChannelsAndPatterns(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	underTest := FromPubSub(client, Payload, Channel("news"), Pattern("sports.*"))
+	results := collect[string](underTest)
+	underTest.Start()
+	awaitSubscribers(t, server, 2)
+	ctx := context.Background()
+	require.NoError(t, client.Publish(ctx, "news", "first").Err())
+	require.NoError(t, client.Publish(ctx, "weather", "ignored").Err())
+	require.NoError(t, client.Publish(ctx, "sports.football", "second").Err())
+	assert.Eventually(t, func() bool {
+		return len(results()) == 2
+	}, time.Second, time.Millisecond)
+	require.NoError(t, underTest.Cancel())
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"first", "second"}, results())
+	assert.Eventually(t, func() bool {
+		return len(server.PubSubChannels("")) == 0 && server.PubSubNumPat() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestFromPubSub_DecodesAndSkipsMalformedMessages(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	underTest := FromPubSub(client, func(message *redis.Message) (int, error) {
+		return strconv.Atoi(message.Payload)
+	}, Channel("numbers"))
+	results := collect[int](underTest)
+	underTest.Start()
+	awaitSubscribers(t, server, 1)
+	for _, payload := range []string{"1", "two", "3"} {
+		require.NoError(t, client.Publish(context.Background(), "numbers", payload).Err())
+	}
+	assert.Eventually(t, func() bool {
+		return len(results()) == 2
+	}, time.Second, time.Millisecond)
+	require.NoError(t, underTest.Cancel())
+	underTest.AwaitCompletion()
+	assert.Equal(t, []int{1, 3}, results())
+}
+
+func TestFromPubSub_ResubscribesAfterReconnect(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	underTest := FromPubSub(client, Payload, Channel("news"))
+	results := collect[string](underTest)
+	underTest.Start()
+	awaitSubscribers(t, server, 1)
+	require.NoError(t, client.Publish(context.Background(), "news", "first").Err())
+	assert.Eventually(t, func() bool {
+		return len(results()) == 1
+	}, time.Second, time.Millisecond)
+	server.Close()
+	require.NoError(t, server.Restart())
+	assert.Eventually(t, func() bool {
+		if len(server.PubSubChannels("")) == 0 {
+			return false
+		}
+		return client.Publish(context.Background(), "news", "second").Val() > 0
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return len(results()) == 2
+	}, time.Second, time.Millisecond)
+	require.NoError(t, underTest.Cancel())
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"first", "second"}, results())
+}
+
+func TestFromPubSub_SubscribesOnStart(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	underTest := FromPubSub(client, Payload, Channel("news"))
+	assert.Empty(t, server.PubSubChannels(""))
+	assert.Zero(t, server.CurrentConnectionCount())
+	require.NoError(t, underTest.Cancel())
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Empty(t, server.PubSubChannels(""))
+}
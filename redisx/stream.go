@@ -0,0 +1,175 @@
+// Package redisx provides [reactive.Source] and [reactive.Sink] implementations backed by Redis, using go-redis.
+package redisx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/AndreasChristianson/gopher-pipes/reactive"
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamGroupOptions configures [FromStreamGroup]. Zero values are replaced with the documented defaults.
+type StreamGroupOptions struct {
+	// Count is the maximum number of entries read per XREADGROUP or XAUTOCLAIM call. Defaults to 10.
+	Count int64
+	// Block is how long XREADGROUP waits for new entries. A cancelled source stops once the waiting read returns, so
+	// Block also bounds how long cancellation takes. Defaults to 1s.
+	Block time.Duration
+	// MinIdle is how long an entry must have been pending, delivered but not acknowledged, before this consumer
+	// reclaims it with XAUTOCLAIM. Defaults to 1m.
+	MinIdle time.Duration
+	// StartID is the id the group starts reading after when FromStreamGroup creates it. Defaults to "$", only new
+	// entries.
+	StartID string
+	// Backoff is applied after consecutive Redis errors. Defaults to reactive.ExponentialBackoff(125ms, 10s).
+	Backoff reactive.Backoff
+}
+
+type streamGroup struct {
+	client   redis.Cmdable
+	stream   string
+	group    string
+	consumer string
+	options  StreamGroupOptions
+	created  bool
+	// cursor is the XAUTOCLAIM position; "0-0" once a scan of the pending entries has finished.
+	cursor    string
+	lastClaim time.Time
+}
+
+// FromStreamGroup returns a [reactive.CancellableSource] of the entries of a Redis stream, read as the named consumer
+// of a consumer group with XREADGROUP. The group is created, along with the stream, if it does not exist.
+//
+// Delivery is at least once: an entry is acknowledged with XACK only after every sink has handled it without error.
+// Entries left pending, because a sink failed or a consumer died, are reclaimed with XAUTOCLAIM once they have been
+// idle for MinIdle, and delivered again.
+func FromStreamGroup(
+	client redis.Cmdable,
+	stream string,
+	group string,
+	consumer string,
+	options StreamGroupOptions,
+) reactive.CancellableSource[redis.XMessage] {
+	if options.Count <= 0 {
+		options.Count = 10
+	}
+	if options.Block <= 0 {
+		options.Block = time.Second
+	}
+	if options.MinIdle <= 0 {
+		options.MinIdle = time.Minute
+	}
+	if options.StartID == "" {
+		options.StartID = "$"
+	}
+	if options.Backoff == nil {
+		options.Backoff = reactive.ExponentialBackoff(125*time.Millisecond, 10*time.Second)
+	}
+	g := &streamGroup{
+		client:   client,
+		stream:   stream,
+		group:    group,
+		consumer: consumer,
+		options:  options,
+		cursor:   "0-0",
+	}
+	return reactive.FromBatchGeneratorWithAck(g.poll, g.ack, options.Backoff, nil)
+}
+
+func (g *streamGroup) poll() ([]redis.XMessage, error) {
+	if !g.created {
+		if err := g.createGroup(); err != nil {
+			return nil, err
+		}
+		g.created = true
+	}
+	if g.cursor != "0-0" || time.Since(g.lastClaim) >= g.options.MinIdle {
+		messages, err := g.claim()
+		if err != nil || len(messages) > 0 {
+			return messages, err
+		}
+	}
+	streams, err := g.client.XReadGroup(context.Background(), &redis.XReadGroupArgs{
+		Group:    g.group,
+		Consumer: g.consumer,
+		Streams:  []string{g.stream, ">"},
+		Count:    g.options.Count,
+		Block:    g.options.Block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var messages []redis.XMessage
+	for _, stream := range streams {
+		messages = append(messages, stream.Messages...)
+	}
+	return messages, nil
+}
+
+func (g *streamGroup) createGroup() error {
+	err := g.client.XGroupCreateMkStream(context.Background(), g.stream, g.group, g.options.StartID).Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+// claim continues the scan of pending entries, starting a new one when the last has finished.
+func (g *streamGroup) claim() ([]redis.XMessage, error) {
+	if g.cursor == "0-0" {
+		g.lastClaim = time.Now()
+	}
+	messages, cursor, err := g.client.XAutoClaim(context.Background(), &redis.XAutoClaimArgs{
+		Stream:   g.stream,
+		Group:    g.group,
+		Consumer: g.consumer,
+		MinIdle:  g.options.MinIdle,
+		Start:    g.cursor,
+		Count:    g.options.Count,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	g.cursor = cursor
+	return messages, nil
+}
+
+func (g *streamGroup) ack(message redis.XMessage) error {
+	return g.client.XAck(context.Background(), g.stream, g.group, message.ID).Err()
+}
+
+// StreamSinkOptions configures [StreamSink].
+type StreamSinkOptions struct {
+	// MaxLen trims the stream to about this many entries on each XADD. Zero disables trimming.
+	MaxLen int64
+	// Exact trims to exactly MaxLen entries rather than letting Redis trim lazily ("MAXLEN ~"), which is cheaper.
+	Exact bool
+}
+
+// StreamSink returns a [reactive.Sink] appending each item to a Redis stream with XADD. The item is encoded into the
+// entry's field value pairs by the provided function.
+func StreamSink[T any](
+	client redis.Cmdable,
+	stream string,
+	encode func(T) (map[string]interface{}, error),
+	options StreamSinkOptions,
+) reactive.Sink[T] {
+	return func(item T) error {
+		values, err := encode(item)
+		if err != nil {
+			return err
+		}
+		return client.XAdd(context.Background(), &redis.XAddArgs{
+			Stream: stream,
+			MaxLen: options.MaxLen,
+			Approx: !options.Exact,
+			Values: values,
+		}).Err()
+	}
+}
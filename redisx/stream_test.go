@@ -0,0 +1,142 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRedis(t *testing.T) *redis.Client {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func encodeData(item string) (map[string]interface{}, error) {
+	return map[string]interface{}{"data": item}, nil
+}
+
+func addAll(t *testing.T, client *redis.Client, items ...string) {
+	sink := StreamSink(client, "test-stream", encodeData, StreamSinkOptions{})
+	for _, item := range items {
+		require.NoError(t, sink(item))
+	}
+}
+
+type recorder struct {
+	lock  sync.Mutex
+	items []string
+}
+
+func (r *recorder) record(message redis.XMessage) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.items = append(r.items, fmt.Sprint(message.Values["data"]))
+}
+
+func (r *recorder) get() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string(nil), r.items...)
+}
+
+func pending(t *testing.T, client *redis.Client) int64 {
+	summary, err := client.XPending(context.Background(), "test-stream", "test-group").Result()
+	require.NoError(t, err)
+	return summary.Count
+}
+
+func TestFromStreamGroup_AcknowledgesDeliveredEntries(t *testing.T) {
+	client := setupRedis(t)
+	addAll(t, client, "first", "second", "third")
+	underTest := FromStreamGroup(client, "test-stream", "test-group", "consumer-1", StreamGroupOptions{
+		StartID: "0",
+		Block:   10 * time.Millisecond,
+	})
+	results := &recorder{}
+	underTest.Observe(func(message redis.XMessage) error {
+		results.record(message)
+		return nil
+	})
+	underTest.Start()
+	assert.Eventually(t, func() bool {
+		return len(results.get()) == 3
+	}, time.Second, time.Millisecond)
+	require.NoError(t, underTest.Cancel())
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"first", "second", "third"}, results.get())
+	assert.Zero(t, pending(t, client))
+}
+
+func TestFromStreamGroup_ReclaimsFailedEntries(t *testing.T) {
+	client := setupRedis(t)
+	underTest := FromStreamGroup(client, "test-stream", "test-group", "consumer-1", StreamGroupOptions{
+		Block:   10 * time.Millisecond,
+		MinIdle: 20 * time.Millisecond,
+	})
+	results := &recorder{}
+	failures := 0
+	underTest.Observe(func(message redis.XMessage) error {
+		results.record(message)
+		if message.Values["data"] == "flaky" && failures == 0 {
+			failures++
+			return errors.New("test error")
+		}
+		return nil
+	})
+	underTest.Start()
+	assert.Eventually(t, func() bool {
+		summary, err := client.XInfoGroups(context.Background(), "test-stream").Result()
+		return err == nil && len(summary) == 1
+	}, time.Second, time.Millisecond)
+	addAll(t, client, "flaky", "steady")
+	assert.Eventually(t, func() bool {
+		return len(results.get()) == 3
+	}, time.Second, time.Millisecond)
+	require.NoError(t, underTest.Cancel())
+	underTest.AwaitCompletion()
+	assert.Equal(t, []string{"flaky", "steady", "flaky"}, results.get())
+	assert.Zero(t, pending(t, client))
+}
+
+func TestFromStreamGroup_CancelStopsAfterBlockedRead(t *testing.T) {
+	client := setupRedis(t)
+	underTest := FromStreamGroup(client, "test-stream", "test-group", "consumer-1", StreamGroupOptions{
+		Block: 50 * time.Millisecond,
+	})
+	underTest.Start()
+	time.Sleep(10 * time.Millisecond)
+	cancelled := time.Now()
+	require.NoError(t, underTest.Cancel())
+	underTest.AwaitCompletion()
+	assert.Less(t, time.Since(cancelled), time.Second)
+}
+
+func TestStreamSink_TrimsStream(t *testing.T) {
+	client := setupRedis(t)
+	sink := StreamSink(client, "test-stream", encodeData, StreamSinkOptions{MaxLen: 2, Exact: true})
+	for _, item := range []string{"first", "second", "third"} {
+		require.NoError(t, sink(item))
+	}
+	messages, err := client.XRange(context.Background(), "test-stream", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "second", messages[0].Values["data"])
+}
+
+func TestStreamSink_ReportsEncodeErrors(t *testing.T) {
+	client := setupRedis(t)
+	sink := StreamSink(client, "test-stream", func(item string) (map[string]interface{}, error) {
+		return nil, errors.New("test error")
+	}, StreamSinkOptions{})
+	assert.Error(t, sink("foobar"))
+}
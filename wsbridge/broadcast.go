@@ -0,0 +1,241 @@
+// Package wsbridge connects [reactive.Source] values to WebSocket connections, using gorilla/websocket.
+package wsbridge
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/AndreasChristianson/gopher-pipes/reactive"
+	"github.com/gorilla/websocket"
+)
+
+// Policy decides what a [Broadcaster] does with a message for a client whose buffer is full.
+type Policy int
+
+const (
+	// DropNewest discards the new message. The client keeps the messages already buffered.
+	DropNewest Policy = iota
+	// DropOldest discards the oldest buffered message to make room for the new one.
+	DropOldest
+	// Disconnect closes the slow client's connection with a "try again later" close frame.
+	Disconnect
+	// Block waits for the client to make room, slowing the observed Source down to the slowest client. Other clients
+	// are still offered the message first, and may connect or disconnect while the Broadcaster waits.
+	Block
+)
+
+// Options configures a [Broadcaster]. Zero values are replaced with the documented defaults.
+type Options struct {
+	// Buffer is the number of messages held for each client. Defaults to 16.
+	Buffer int
+	// Policy applies when a client's buffer is full. Defaults to DropNewest.
+	Policy Policy
+	// WriteTimeout limits each write to a client; a client that times out is disconnected. Defaults to 10s.
+	WriteTimeout time.Duration
+	// MessageType is websocket.TextMessage or websocket.BinaryMessage. Defaults to websocket.TextMessage.
+	MessageType int
+	// Upgrader upgrades incoming requests. Defaults to a zero websocket.Upgrader, which only accepts same origin
+	// requests.
+	Upgrader *websocket.Upgrader
+}
+
+type client struct {
+	send   chan []byte
+	kicked chan struct{}
+	done   chan struct{}
+}
+
+// Broadcaster is an [http.Handler] fanning the items of a [reactive.Source] out to every connected WebSocket client.
+// Clients only receive items observed while they are connected, and are unsubscribed when they disconnect. Once the
+// Source closes, clients are sent their buffered messages and a normal close frame, and later requests are answered
+// 503 Service Unavailable.
+type Broadcaster[T any] struct {
+	encode  func(T) ([]byte, error)
+	options Options
+	lock    sync.Mutex
+	clients map[*client]struct{}
+	closed  bool
+	// sending counts broadcasts waiting on blocked clients, which close waits for before closing their channels.
+	sending sync.WaitGroup
+}
+
+// NewBroadcaster returns a [Broadcaster] of the provided [reactive.Source], encoding each item once with the provided
+// function. NewBroadcaster observes the Source, so it should be called before the Source is started.
+func NewBroadcaster[T any](source reactive.Source[T], encode func(T) ([]byte, error), options Options) *Broadcaster[T] {
+	if options.Buffer <= 0 {
+		options.Buffer = 16
+	}
+	if options.WriteTimeout <= 0 {
+		options.WriteTimeout = 10 * time.Second
+	}
+	if options.MessageType == 0 {
+		options.MessageType = websocket.TextMessage
+	}
+	if options.Upgrader == nil {
+		options.Upgrader = &websocket.Upgrader{}
+	}
+	ret := &Broadcaster[T]{
+		encode:  encode,
+		options: options,
+		clients: map[*client]struct{}{},
+	}
+	source.Observe(ret.broadcast)
+	source.UponClose(ret.close)
+	return ret
+}
+
+// Clients returns the number of connected clients.
+func (b *Broadcaster[T]) Clients() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return len(b.clients)
+}
+
+func (b *Broadcaster[T]) broadcast(item T) error {
+	message, err := b.encode(item)
+	if err != nil {
+		return err
+	}
+	b.lock.Lock()
+	if b.closed {
+		b.lock.Unlock()
+		return nil
+	}
+	var blocked []*client
+	for c := range b.clients {
+		if !b.offer(c, message) {
+			blocked = append(blocked, c)
+		}
+	}
+	b.sending.Add(1)
+	b.lock.Unlock()
+	defer b.sending.Done()
+	for _, c := range blocked {
+		select {
+		case c.send <- message:
+		case <-c.done:
+		}
+	}
+	return nil
+}
+
+// offer must be called while holding the lock. It returns false when the Block policy applies to a full client, which
+// the caller must wait on after releasing the lock.
+func (b *Broadcaster[T]) offer(c *client, message []byte) bool {
+	select {
+	case c.send <- message:
+		return true
+	default:
+	}
+	switch b.options.Policy {
+	case DropOldest:
+		for {
+			select {
+			case <-c.send:
+			default:
+			}
+			select {
+			case c.send <- message:
+				return true
+			default:
+			}
+		}
+	case Disconnect:
+		delete(b.clients, c)
+		close(c.kicked)
+	case Block:
+		return false
+	}
+	return true
+}
+
+func (b *Broadcaster[T]) close() {
+	b.lock.Lock()
+	b.closed = true
+	clients := b.clients
+	b.clients = nil
+	b.lock.Unlock()
+	b.sending.Wait()
+	for c := range clients {
+		close(c.send)
+	}
+}
+
+func (b *Broadcaster[T]) subscribe() (*client, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		return nil, false
+	}
+	c := &client{
+		send:   make(chan []byte, b.options.Buffer),
+		kicked: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	b.clients[c] = struct{}{}
+	return c, true
+}
+
+func (b *Broadcaster[T]) unsubscribe(c *client) {
+	close(c.done)
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.clients, c)
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and streams items to it until either side closes.
+func (b *Broadcaster[T]) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	b.lock.Lock()
+	closed := b.closed
+	b.lock.Unlock()
+	if closed {
+		http.Error(writer, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	conn, err := b.options.Upgrader.Upgrade(writer, request, nil)
+	if err != nil {
+		// the upgrader has already responded
+		return
+	}
+	defer conn.Close()
+	c, ok := b.subscribe()
+	if !ok {
+		b.closeConn(conn, websocket.CloseGoingAway, "source closed")
+		return
+	}
+	defer b.unsubscribe(c)
+	gone := make(chan struct{})
+	go func() {
+		// Reading processes control frames and notices the client leaving; clients are not expected to send data.
+		defer close(gone)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case <-gone:
+			return
+		case <-c.kicked:
+			b.closeConn(conn, websocket.CloseTryAgainLater, "client too slow")
+			return
+		case message, open := <-c.send:
+			if !open {
+				b.closeConn(conn, websocket.CloseNormalClosure, "")
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(b.options.WriteTimeout))
+			if err := conn.WriteMessage(b.options.MessageType, message); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (b *Broadcaster[T]) closeConn(conn *websocket.Conn, code int, text string) {
+	deadline := time.Now().Add(b.options.WriteTimeout)
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), deadline)
+}
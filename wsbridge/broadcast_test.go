@@ -0,0 +1,176 @@
+package wsbridge
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndreasChristianson/gopher-pipes/reactive"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeString(item string) ([]byte, error) {
+	return []byte(item), nil
+}
+
+func dial(t *testing.T, server *httptest.Server) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func awaitClients[T any](t *testing.T, broadcaster *Broadcaster[T], count int) {
+	assert.Eventually(t, func() bool {
+		return broadcaster.Clients() == count
+	}, time.Second, time.Millisecond)
+}
+
+func TestBroadcaster_FansOutToClients(t *testing.T) {
+	items := make(chan string)
+	source := reactive.FromChan(items)
+	underTest := NewBroadcaster(source, encodeString, Options{})
+	server := httptest.NewServer(underTest)
+	defer server.Close()
+	source.Start()
+	clients := []*websocket.Conn{dial(t, server), dial(t, server)}
+	awaitClients(t, underTest, 2)
+	items <- "foo"
+	items <- "bar"
+	close(items)
+	source.AwaitCompletion()
+	for _, conn := range clients {
+		for _, expected := range []string{"foo", "bar"} {
+			messageType, message, err := conn.ReadMessage()
+			require.NoError(t, err)
+			assert.Equal(t, websocket.TextMessage, messageType)
+			assert.Equal(t, expected, string(message))
+		}
+		_, _, err := conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), err)
+	}
+	awaitClients(t, underTest, 0)
+}
+
+func TestBroadcaster_UnsubscribesDisconnectedClients(t *testing.T) {
+	items := make(chan string)
+	source := reactive.FromChan(items)
+	underTest := NewBroadcaster(source, encodeString, Options{})
+	server := httptest.NewServer(underTest)
+	defer server.Close()
+	source.Start()
+	conn := dial(t, server)
+	awaitClients(t, underTest, 1)
+	require.NoError(t, conn.Close())
+	awaitClients(t, underTest, 0)
+	items <- "foo"
+	close(items)
+	source.AwaitCompletion()
+}
+
+func TestBroadcaster_RefusesAfterClose(t *testing.T) {
+	source := reactive.Just("foo")
+	underTest := NewBroadcaster(source, encodeString, Options{})
+	source.Start()
+	source.AwaitCompletion()
+	server := httptest.NewServer(underTest)
+	defer server.Close()
+	_, response, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+}
+
+func fullClient(buffered ...string) *client {
+	ret := &client{
+		send:   make(chan []byte, len(buffered)),
+		kicked: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	for _, message := range buffered {
+		ret.send <- []byte(message)
+	}
+	return ret
+}
+
+func drain(c *client) []string {
+	var ret []string
+	for len(c.send) > 0 {
+		ret = append(ret, string(<-c.send))
+	}
+	return ret
+}
+
+func TestBroadcaster_Policies(t *testing.T) {
+	t.Run("DropNewest", func(t *testing.T) {
+		underTest := &Broadcaster[string]{options: Options{Policy: DropNewest}}
+		c := fullClient("a", "b")
+		underTest.offer(c, []byte("c"))
+		assert.Equal(t, []string{"a", "b"}, drain(c))
+	})
+	t.Run("DropOldest", func(t *testing.T) {
+		underTest := &Broadcaster[string]{options: Options{Policy: DropOldest}}
+		c := fullClient("a", "b")
+		underTest.offer(c, []byte("c"))
+		assert.Equal(t, []string{"b", "c"}, drain(c))
+	})
+	t.Run("Disconnect", func(t *testing.T) {
+		c := fullClient("a")
+		underTest := &Broadcaster[string]{options: Options{Policy: Disconnect}, clients: map[*client]struct{}{c: {}}}
+		underTest.offer(c, []byte("b"))
+		assert.Empty(t, underTest.clients)
+		select {
+		case <-c.kicked:
+		default:
+			assert.Fail(t, "client should be kicked")
+		}
+	})
+	t.Run("Block", func(t *testing.T) {
+		c := fullClient("a")
+		other := fullClient("x")
+		<-other.send
+		underTest := &Broadcaster[string]{
+			encode:  encodeString,
+			options: Options{Policy: Block},
+			clients: map[*client]struct{}{c: {}, other: {}},
+		}
+		offered := make(chan struct{})
+		go func() {
+			assert.NoError(t, underTest.broadcast("b"))
+			close(offered)
+		}()
+		assert.Eventually(t, func() bool {
+			return len(other.send) == 1
+		}, time.Second, time.Millisecond)
+		select {
+		case <-offered:
+			assert.Fail(t, "broadcast should block while the buffer is full")
+		case <-time.After(10 * time.Millisecond):
+		}
+		assert.Equal(t, 2, underTest.Clients(), "the lock should not be held while blocked")
+		assert.Equal(t, "a", string(<-c.send))
+		<-offered
+		assert.Equal(t, []string{"b"}, drain(c))
+		assert.Equal(t, []string{"b"}, drain(other))
+	})
+	t.Run("BlockedClientLeaves", func(t *testing.T) {
+		c := fullClient("a")
+		underTest := &Broadcaster[string]{
+			encode:  encodeString,
+			options: Options{Policy: Block},
+			clients: map[*client]struct{}{c: {}},
+		}
+		offered := make(chan struct{})
+		go func() {
+			assert.NoError(t, underTest.broadcast("b"))
+			close(offered)
+		}()
+		underTest.unsubscribe(c)
+		<-offered
+		underTest.close()
+		assert.Equal(t, []string{"a"}, drain(c))
+	})
+}
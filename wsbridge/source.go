@@ -0,0 +1,53 @@
+package wsbridge
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/AndreasChristianson/gopher-pipes/reactive"
+	"github.com/gorilla/websocket"
+)
+
+type connSource[T any] struct {
+	reactive.CancellableSource[T]
+	conn      *websocket.Conn
+	closeOnce func() error
+}
+
+// FromConn returns a [reactive.CancellableSource] of the messages read from the provided connection, decoded with
+// the provided function. A message that fails to decode is reported through the generator error path and skipped.
+// The source completes when the connection closes; cancelling it sends a normal close frame and closes the
+// connection.
+func FromConn[T any](conn *websocket.Conn, decode func([]byte) (T, error)) reactive.CancellableSource[T] {
+	ret := &connSource[T]{conn: conn}
+	ret.closeOnce = sync.OnceValue(func() error {
+		deadline := time.Now().Add(time.Second)
+		message := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		_ = conn.WriteControl(websocket.CloseMessage, message, deadline)
+		return conn.Close()
+	})
+	ret.CancellableSource = reactive.FromGeneratorWithBackoff(func() (*T, error) {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return nil, &reactive.GeneratorFinished{}
+			}
+			return nil, errors.Join(err, &reactive.GeneratorFinished{})
+		}
+		item, err := decode(message)
+		if err != nil {
+			return nil, err
+		}
+		return &item, nil
+	}, nil)
+	ret.UponClose(func() {
+		_ = ret.closeOnce()
+	})
+	return ret
+}
+
+// Cancel stops reading and closes the connection.
+func (c *connSource[T]) Cancel() error {
+	return errors.Join(c.CancellableSource.Cancel(), c.closeOnce())
+}
@@ -0,0 +1,68 @@
+package wsbridge
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/AndreasChristianson/gopher-pipes/reactive"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func upgrading(t *testing.T, handle func(conn *websocket.Conn)) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(writer, request, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		handle(conn)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func collect[T any](source reactive.Source[T]) *[]T {
+	var results []T
+	source.Observe(func(item T) error {
+		results = append(results, item)
+		return nil
+	})
+	return &results
+}
+
+func TestFromConn_HappyPath(t *testing.T) {
+	server := upgrading(t, func(conn *websocket.Conn) {
+		for _, message := range []string{"1", "2", "bad", "3"} {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(message)))
+		}
+		require.NoError(t, conn.WriteMessage(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		))
+		_, _, _ = conn.ReadMessage()
+	})
+	underTest := FromConn(dial(t, server), func(message []byte) (int, error) {
+		return strconv.Atoi(string(message))
+	})
+	results := collect[int](underTest)
+	underTest.Start()
+	underTest.AwaitCompletion()
+	assert.Equal(t, []int{1, 2, 3}, *results)
+}
+
+func TestFromConn_CancelClosesConnection(t *testing.T) {
+	closed := make(chan error, 1)
+	server := upgrading(t, func(conn *websocket.Conn) {
+		_, _, err := conn.ReadMessage()
+		closed <- err
+	})
+	underTest := FromConn(dial(t, server), func(message []byte) (string, error) {
+		return string(message), nil
+	})
+	underTest.Start()
+	assert.NoError(t, underTest.Cancel())
+	underTest.AwaitCompletion()
+	assert.True(t, websocket.IsCloseError(<-closed, websocket.CloseNormalClosure))
+}